@@ -0,0 +1,603 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	apiTokenEnv = "IT488_API_TOKEN"
+
+	// apiMaxBodyBytes caps request bodies, descriptions included.
+	apiMaxBodyBytes = 1 << 20
+)
+
+// apiError is returned by handlers to produce a JSON error response with a specific status code.
+type apiError struct {
+	status int
+	msg    string
+}
+
+func (e apiError) Error() string {
+	return e.msg
+}
+
+func apiErrorf(status int, f string, v ...any) error {
+	return apiError{status: status, msg: fmt.Sprintf(f, v...)}
+}
+
+type apiTaskListInput struct {
+	Label       *string `json:"label"`
+	Description *string `json:"description"`
+	Color       *string `json:"color"`
+}
+
+// apiTaskInput is the body accepted when creating or patching a task.  Omitted fields are left unchanged; a list_id
+// of 0 removes the task from its list.
+type apiTaskInput struct {
+	ListID      *uint     `json:"list_id"`
+	Label       *string   `json:"label"`
+	Description *string   `json:"description"`
+	Status      *string   `json:"status"`
+	Priority    *string   `json:"priority"`
+	DueDate     *string   `json:"due_date"`
+	Recurrence  *string   `json:"recurrence"`
+	Tags        *[]string `json:"tags"`
+}
+
+type apiStatusInput struct {
+	Status string `json:"status"`
+}
+
+type apiPriorityInput struct {
+	Priority string `json:"priority"`
+}
+
+type apiStatusOutput struct {
+	Task TaskJSON  `json:"task"`
+	Next *TaskJSON `json:"next,omitempty"`
+}
+
+type apiHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// APIServer serves a token protected JSON API over the task database.
+type APIServer struct {
+	db    *DBRef
+	token string
+	mux   *http.ServeMux
+}
+
+func NewAPIServer(db *DBRef, token string) *APIServer {
+	s := APIServer{
+		db:    db,
+		token: token,
+		mux:   http.NewServeMux(),
+	}
+
+	s.handle("GET /api/v1/lists", s.listTaskLists)
+	s.handle("POST /api/v1/lists", s.createTaskList)
+	s.handle("GET /api/v1/lists/{id}", s.getTaskList)
+	s.handle("PATCH /api/v1/lists/{id}", s.updateTaskList)
+	s.handle("DELETE /api/v1/lists/{id}", s.deleteTaskList)
+	s.handle("GET /api/v1/lists/{id}/tasks", s.listTaskListTasks)
+
+	s.handle("GET /api/v1/tasks", s.listTasks)
+	s.handle("GET /api/v1/tasks/today", s.listTodaysTasks)
+	s.handle("POST /api/v1/tasks", s.createTask)
+	s.handle("GET /api/v1/tasks/{id}", s.getTask)
+	s.handle("PATCH /api/v1/tasks/{id}", s.updateTask)
+	s.handle("DELETE /api/v1/tasks/{id}", s.deleteTask)
+	s.handle("PUT /api/v1/tasks/{id}/status", s.setTaskStatus)
+	s.handle("PUT /api/v1/tasks/{id}/priority", s.setTaskPriority)
+
+	return &s
+}
+
+// generateAPIToken returns a random token for when none was configured.
+func generateAPIToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ListenAndServe runs the API until ctx is cancelled.
+func (s *APIServer) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	log.Info("Starting API server", "addr", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *APIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeAPIError(w, apiErrorf(http.StatusUnauthorized, "missing or invalid API token"))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *APIServer) handle(pattern string, fn apiHandlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, apiMaxBodyBytes)
+		if err := fn(w, r); err != nil {
+			log.Debug("API request failed", "method", r.Method, "path", r.URL.Path, "err", err)
+			writeAPIError(w, err)
+		}
+	})
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var apiErr apiError
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.status
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status = http.StatusNotFound
+	}
+	_ = writeAPIJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func decodeAPIBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return apiErrorf(http.StatusBadRequest, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func apiPathID(r *http.Request) (uint, error) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, apiErrorf(http.StatusBadRequest, "invalid ID %q", r.PathValue("id"))
+	}
+	return uint(id), nil
+}
+
+func (s *APIServer) findTaskList(ctx context.Context, id uint) (*TaskList, error) {
+	taskList, err := FindOneModel[TaskList](ctx, s.db.Get(), func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if taskList == nil {
+		return nil, apiErrorf(http.StatusNotFound, "task list %d not found", id)
+	}
+	return taskList, nil
+}
+
+func (s *APIServer) findTask(ctx context.Context, id uint) (*Task, error) {
+	task, err := FindOneModel[Task](ctx, s.db.Get(), WithPreload("TaskList"), WithPreload("Tags"), func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, apiErrorf(http.StatusNotFound, "task %d not found", id)
+	}
+	return task, nil
+}
+
+func (s *APIServer) writeTasks(w http.ResponseWriter, r *http.Request, opts ...ModelQueryOpt) error {
+	tasks, err := FindModel[Task](r.Context(), s.db.Get(), append(opts, WithPreload("Tags"))...)
+	if err != nil {
+		return err
+	}
+	out := make([]TaskJSON, len(tasks))
+	for i := range tasks {
+		out[i] = NewTaskJSON(tasks[i])
+	}
+	return writeAPIJSON(w, http.StatusOK, out)
+}
+
+func (s *APIServer) listTaskLists(w http.ResponseWriter, r *http.Request) error {
+	taskLists, err := FindModel[TaskList](r.Context(), s.db.Get(), WithSort("date asc"))
+	if err != nil {
+		return err
+	}
+	out := make([]TaskListJSON, len(taskLists))
+	for i := range taskLists {
+		out[i] = NewTaskListJSON(taskLists[i])
+	}
+	return writeAPIJSON(w, http.StatusOK, out)
+}
+
+func (s *APIServer) getTaskList(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	taskList, err := s.findTaskList(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return writeAPIJSON(w, http.StatusOK, NewTaskListJSON(*taskList))
+}
+
+func (s *APIServer) createTaskList(w http.ResponseWriter, r *http.Request) error {
+	var in apiTaskListInput
+	if err := decodeAPIBody(r, &in); err != nil {
+		return err
+	}
+	if in.Label == nil || strings.TrimSpace(*in.Label) == "" {
+		return apiErrorf(http.StatusBadRequest, "label is required")
+	}
+	taskList := TaskList{
+		Label: *in.Label,
+		Date:  time.Now(),
+	}
+	if in.Description != nil {
+		taskList.Description = *in.Description
+	}
+	if in.Color != nil {
+		if err := ValidateListColor(*in.Color); err != nil {
+			return apiErrorf(http.StatusBadRequest, "%v", err)
+		}
+		taskList.Color = NormalizeListColor(*in.Color)
+	}
+	if err := s.db.Get().WithContext(r.Context()).Create(&taskList).Error; err != nil {
+		return err
+	}
+	return writeAPIJSON(w, http.StatusCreated, NewTaskListJSON(taskList))
+}
+
+func (s *APIServer) updateTaskList(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	var in apiTaskListInput
+	if err = decodeAPIBody(r, &in); err != nil {
+		return err
+	}
+	taskList, err := s.findTaskList(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if in.Label != nil {
+		if strings.TrimSpace(*in.Label) == "" {
+			return apiErrorf(http.StatusBadRequest, "label cannot be empty")
+		}
+		taskList.Label = *in.Label
+	}
+	if in.Description != nil {
+		taskList.Description = *in.Description
+	}
+	if in.Color != nil {
+		if err = ValidateListColor(*in.Color); err != nil {
+			return apiErrorf(http.StatusBadRequest, "%v", err)
+		}
+		taskList.Color = NormalizeListColor(*in.Color)
+	}
+	res := s.db.Get().WithContext(r.Context()).Model(taskList).Select("Label", "Description", "Color").Updates(taskList)
+	if res.Error != nil {
+		return res.Error
+	}
+	return writeAPIJSON(w, http.StatusOK, NewTaskListJSON(*taskList))
+}
+
+func (s *APIServer) deleteTaskList(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	taskList, err := s.findTaskList(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if err = DeleteTaskList(s.db.Get().WithContext(r.Context()), taskList); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *APIServer) listTaskListTasks(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	if _, err = s.findTaskList(r.Context(), id); err != nil {
+		return err
+	}
+	return s.writeTasks(w, r, WithPreload("TaskList"), WithSort("due_date asc"), WithSort("id asc"), func(db *gorm.DB) *gorm.DB {
+		return db.Where("task_list_id = ?", id)
+	})
+}
+
+func (s *APIServer) listTasks(w http.ResponseWriter, r *http.Request) error {
+	opts := []ModelQueryOpt{WithPreload("TaskList"), WithSort("due_date asc"), WithSort("id asc")}
+	query := r.URL.Query()
+	if v := query.Get("status"); v != "" {
+		status, err := ParseTaskStatus(v)
+		if err != nil {
+			return apiErrorf(http.StatusBadRequest, "%v", err)
+		}
+		opts = append(opts, func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", status)
+		})
+	}
+	if v := query.Get("list_id"); v != "" {
+		listID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return apiErrorf(http.StatusBadRequest, "invalid list_id %q", v)
+		}
+		opts = append(opts, func(db *gorm.DB) *gorm.DB {
+			return db.Where("task_list_id = ?", listID)
+		})
+	}
+	if v := query.Get("tag"); v != "" {
+		tag, err := FindOneModel[Tag](r.Context(), s.db.Get(), func(db *gorm.DB) *gorm.DB {
+			return db.Where("lower(label) = lower(?)", v)
+		})
+		if err != nil {
+			return err
+		}
+		if tag == nil {
+			return writeAPIJSON(w, http.StatusOK, []TaskJSON{})
+		}
+		opts = append(opts, tagTasksModelQueryOpt(tag.ID))
+	}
+	return s.writeTasks(w, r, opts...)
+}
+
+func (s *APIServer) listTodaysTasks(w http.ResponseWriter, r *http.Request) error {
+	return s.writeTasks(w, r, todaysTasksModelQueryOpt())
+}
+
+func (s *APIServer) getTask(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	task, err := s.findTask(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return writeAPIJSON(w, http.StatusOK, NewTaskJSON(*task))
+}
+
+// applyTaskInput copies the provided fields onto task, returning the names of the columns that changed.
+func (s *APIServer) applyTaskInput(ctx context.Context, task *Task, in apiTaskInput) ([]string, error) {
+	columns := make([]string, 0)
+	if in.Label != nil {
+		if strings.TrimSpace(*in.Label) == "" {
+			return nil, apiErrorf(http.StatusBadRequest, "label cannot be empty")
+		}
+		task.Label = *in.Label
+		columns = append(columns, "Label")
+	}
+	if in.Description != nil {
+		task.Description = *in.Description
+		columns = append(columns, "Description")
+	}
+	if in.Status != nil {
+		status, err := ParseTaskStatus(*in.Status)
+		if err != nil {
+			return nil, apiErrorf(http.StatusBadRequest, "%v", err)
+		}
+		task.Status = status
+		columns = append(columns, "Status")
+	}
+	if in.Priority != nil {
+		priority, err := ParseTaskPriority(*in.Priority)
+		if err != nil {
+			return nil, apiErrorf(http.StatusBadRequest, "%v", err)
+		}
+		task.UserPriority = priority
+		columns = append(columns, "UserPriority")
+	}
+	if in.DueDate != nil {
+		dueDate, err := ParseDateTime(*in.DueDate)
+		if err != nil {
+			return nil, apiErrorf(http.StatusBadRequest, "%v", err)
+		}
+		task.DueDate = dueDate
+		columns = append(columns, "DueDate")
+	}
+	if in.Recurrence != nil {
+		task.Recurrence = ""
+		if *in.Recurrence != "" {
+			rule, err := ParseTaskRecurrence(*in.Recurrence)
+			if err != nil {
+				return nil, apiErrorf(http.StatusBadRequest, "%v", err)
+			}
+			task.Recurrence = rule.String()
+		}
+		columns = append(columns, "Recurrence")
+	}
+	if in.ListID != nil {
+		task.TaskList = nil
+		task.TaskListID = sql.Null[int]{}
+		if *in.ListID != 0 {
+			if _, err := s.findTaskList(ctx, *in.ListID); err != nil {
+				return nil, err
+			}
+			task.TaskListID = sql.Null[int]{V: int(*in.ListID), Valid: true}
+		}
+		columns = append(columns, "TaskListID")
+	}
+	return columns, nil
+}
+
+func (s *APIServer) createTask(w http.ResponseWriter, r *http.Request) error {
+	var in apiTaskInput
+	if err := decodeAPIBody(r, &in); err != nil {
+		return err
+	}
+	if in.Label == nil {
+		return apiErrorf(http.StatusBadRequest, "label is required")
+	}
+	task := Task{
+		Status:       TaskStatusTodo,
+		UserPriority: TaskPriorityNumber(TaskPriorityHigh),
+		DueDate:      time.Now(),
+		SortOrder:    GetNextTaskOrderNum(),
+	}
+	if _, err := s.applyTaskInput(r.Context(), &task, in); err != nil {
+		return err
+	}
+	err := s.db.Get().WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := CreateTask(tx, &task); err != nil {
+			return err
+		}
+		if in.Tags != nil {
+			return SetTaskTags(tx, task.ID, ParseTagLabels(strings.Join(*in.Tags, ",")))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	created, err := s.findTask(r.Context(), task.ID)
+	if err != nil {
+		return err
+	}
+	return writeAPIJSON(w, http.StatusCreated, NewTaskJSON(*created))
+}
+
+func (s *APIServer) updateTask(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	var in apiTaskInput
+	if err = decodeAPIBody(r, &in); err != nil {
+		return err
+	}
+	task, err := s.findTask(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	columns, err := s.applyTaskInput(r.Context(), task, in)
+	if err != nil {
+		return err
+	}
+	err = s.db.Get().WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		if len(columns) > 0 {
+			if err := tx.Model(task).Select(columns).Updates(task).Error; err != nil {
+				return err
+			}
+		}
+		if in.Tags != nil {
+			return SetTaskTags(tx, task.ID, ParseTagLabels(strings.Join(*in.Tags, ",")))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	updated, err := s.findTask(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return writeAPIJSON(w, http.StatusOK, NewTaskJSON(*updated))
+}
+
+func (s *APIServer) deleteTask(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	task, err := s.findTask(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if err = s.db.Get().WithContext(r.Context()).Delete(task).Error; err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *APIServer) setTaskStatus(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	var in apiStatusInput
+	if err = decodeAPIBody(r, &in); err != nil {
+		return err
+	}
+	status, err := ParseTaskStatus(in.Status)
+	if err != nil {
+		return apiErrorf(http.StatusBadRequest, "%v", err)
+	}
+	task, err := s.findTask(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	next, err := UpdateTaskStatus(s.db.Get().WithContext(r.Context()), task, status)
+	if err != nil {
+		return err
+	}
+	out := apiStatusOutput{Task: NewTaskJSON(*task)}
+	if next != nil {
+		if next, err = s.findTask(r.Context(), next.ID); err != nil {
+			return err
+		}
+		nextJSON := NewTaskJSON(*next)
+		out.Next = &nextJSON
+	}
+	return writeAPIJSON(w, http.StatusOK, out)
+}
+
+func (s *APIServer) setTaskPriority(w http.ResponseWriter, r *http.Request) error {
+	id, err := apiPathID(r)
+	if err != nil {
+		return err
+	}
+	var in apiPriorityInput
+	if err = decodeAPIBody(r, &in); err != nil {
+		return err
+	}
+	priority, err := ParseTaskPriority(in.Priority)
+	if err != nil {
+		return apiErrorf(http.StatusBadRequest, "%v", err)
+	}
+	task, err := s.findTask(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if err = s.db.Get().WithContext(r.Context()).Model(task).Update("UserPriority", priority).Error; err != nil {
+		return err
+	}
+	return writeAPIJSON(w, http.StatusOK, NewTaskJSON(*task))
+}
@@ -1,16 +1,32 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/driver/mobile"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"gorm.io/gorm"
 )
 
+const (
+	// navigationHistoryLimit is the number of views remembered for going back.
+	navigationHistoryLimit = 50
+
+	// appHeaderButtons is the number of buttons at the start of the header, before the active view's title.
+	appHeaderButtons = 2
+)
+
 func logAppLifecycle(a fyne.App) {
 	a.Lifecycle().SetOnStarted(func() {
 		log.Debug("Lifecycle: Started")
@@ -31,64 +47,137 @@ type TaskApp struct {
 
 	fyneApp fyne.App
 	window  fyne.Window
-	db      *gorm.DB
+	db      *DBRef
+	backups BackupConfig
+	theme   *TodoTodayTheme
 
 	container      *fyne.Container
 	body           *fyne.Container
+	background     *canvas.Rectangle
 	contentWrapper *fyne.Container
 	activeView     View
-	previousView   View
+	history        []View
 
 	showNavBtn *widget.Button
+	backBtn    *widget.Button
 	appHeader  *fyne.Container
+
+	undo       *UndoStack
+	undoBar    *fyne.Container
+	undoLabel  *widget.Label
+	undoBarGen atomic.Uint64
 }
 
-func newTaskApp(fyneApp fyne.App, window fyne.Window, db *gorm.DB) *TaskApp {
+func newTaskApp(fyneApp fyne.App, window fyne.Window, db *DBRef, backups BackupConfig) *TaskApp {
 	ta := TaskApp{
 		fyneApp: fyneApp,
 		window:  window,
 		db:      db,
+		backups: backups,
+		undo:    newUndoStack(),
+		theme:   NewTheme(),
 	}
 
-	fyneApp.Settings().SetTheme(NewTheme())
+	settings := LoadSettings(fyneApp.Preferences())
+	ta.theme.SetVariant(settings.ThemeVariant)
+	fyneApp.Settings().SetTheme(ta.theme)
+	fyneApp.Settings().AddListener(func(fyne.Settings) {
+		ta.applyTheme()
+	})
+	SetDisplayFormat(settings.DisplayFormat())
 
 	ta.showNavBtn = widget.NewButtonWithIcon("", theme.ListIcon(), func() {
 		ta.RenderNavigation()
 	})
 
+	ta.backBtn = widget.NewButtonWithIcon("", theme.NavigateBackIcon(), ta.Back)
+	ta.backBtn.Hide()
+
 	ta.contentWrapper = container.NewStack()
 
-	ta.appHeader = container.NewHBox(ta.showNavBtn)
+	ta.appHeader = container.NewHBox(ta.showNavBtn, ta.backBtn)
+
+	ta.undoLabel = widget.NewLabel("")
+	ta.undoLabel.Truncation = fyne.TextTruncateEllipsis
+	ta.undoBar = container.NewBorder(
+		nil,
+		nil,
+		nil,
+		widget.NewButtonWithIcon("Undo", theme.ContentUndoIcon(), ta.Undo),
+		ta.undoLabel,
+	)
+	ta.undoBar.Hide()
 
 	ta.body = container.NewBorder(
 		ta.appHeader,
-		widget.NewButton("Quit", func() { fyneApp.Quit() }),
+		container.NewVBox(
+			ta.undoBar,
+			widget.NewButton("Quit", ta.Quit),
+		),
 		nil,
 		nil,
 		ta.contentWrapper,
 	)
 
+	ta.background = canvas.NewRectangle(theme.Color(theme.ColorNameBackground))
 	ta.container = container.NewStack(
-		canvas.NewRectangle(ColorBackground),
+		ta.background,
 		ta.body,
 	)
 
+	window.Canvas().AddShortcut(
+		&desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault},
+		func(fyne.Shortcut) { ta.Undo() },
+	)
+	window.SetCloseIntercept(ta.Quit)
+	window.Canvas().SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		// Fyne doesn't report mouse back buttons, the mobile back key stands in for them.
+		if ev.Name == fyne.KeyEscape || ev.Name == mobile.KeyBack {
+			ta.Back()
+		}
+	})
+
 	return &ta
 }
 
+// renderView pushes view onto the navigation history.  The navigation menu is never returned to, so it isn't kept.
 func (ta *TaskApp) renderView(view View) {
 	if ta.activeView != nil {
 		ta.activeView.Background()
-		ta.previousView = ta.activeView
+		if _, ok := ta.activeView.(*NavigationView); !ok {
+			ta.history = append(ta.history, ta.activeView)
+			if l := len(ta.history); l > navigationHistoryLimit {
+				ta.history = ta.history[l-navigationHistoryLimit:]
+			}
+		}
 	}
-	if ta.showNavBtn.Hidden {
-		ta.showNavBtn.Show()
+	ta.showView(view)
+}
+
+// replaceView shows view in place of the active view, which isn't kept in the history.
+func (ta *TaskApp) replaceView(view View) {
+	if ta.activeView != nil {
+		ta.activeView.Background()
 	}
+	ta.showView(view)
+}
+
+func (ta *TaskApp) showView(view View) {
 	ta.contentWrapper.RemoveAll()
 	ta.activeView = view
-	if l := len(ta.appHeader.Objects); l > 1 {
-		for i := 1; i < l; i++ {
-			ta.appHeader.Remove(ta.appHeader.Objects[1])
+	if _, ok := view.(*NavigationView); ok {
+		ta.showNavBtn.Hide()
+	} else {
+		ta.showNavBtn.Show()
+	}
+	if len(ta.history) > 0 {
+		ta.backBtn.Show()
+	} else {
+		ta.backBtn.Hide()
+	}
+	if l := len(ta.appHeader.Objects); l > appHeaderButtons {
+		for i := appHeaderButtons; i < l; i++ {
+			ta.appHeader.Remove(ta.appHeader.Objects[appHeaderButtons])
 		}
 	}
 	if title := view.Title(); len(title) > 0 {
@@ -97,29 +186,65 @@ func (ta *TaskApp) renderView(view View) {
 		}
 	}
 	ta.contentWrapper.Add(ta.activeView.Foreground())
+	ta.rememberView(view)
+}
+
+// ActiveRoute returns the route of the view being shown.
+func (ta *TaskApp) ActiveRoute() string {
+	ta.mu.RLock()
+	defer ta.mu.RUnlock()
+	if ta.activeView == nil {
+		return ""
+	}
+	return ta.activeView.Route()
 }
 
-func (ta *TaskApp) PreviousView() View {
+// Back returns to the previous view, or home when there is none.
+func (ta *TaskApp) Back() {
 	ta.mu.Lock()
 	defer ta.mu.Unlock()
-	return ta.previousView
+	ta.back()
+}
+
+func (ta *TaskApp) back() {
+	l := len(ta.history)
+	if l == 0 {
+		if _, ok := ta.activeView.(*HomeView); !ok {
+			ta.replaceView(NewHomeView(ta))
+		}
+		return
+	}
+	previous := ta.history[l-1]
+	ta.history = ta.history[:l-1]
+	ta.replaceView(previous)
 }
 
-func (ta *TaskApp) RenderPreviousView() {
+// BackFrom returns to the most recent view that isn't route or beneath it, for when what route shows is deleted.
+func (ta *TaskApp) BackFrom(route string) {
 	ta.mu.Lock()
 	defer ta.mu.Unlock()
-	if ta.previousView != nil {
-		ta.renderView(ta.previousView)
-	} else {
-		ta.renderView(NewHomeView(ta))
+	ta.history = slices.DeleteFunc(ta.history, func(v View) bool {
+		return routeUnder(v.Route(), route)
+	})
+	ta.back()
+}
+
+// Navigate pushes the view a route names.
+func (ta *TaskApp) Navigate(route string) error {
+	view, err := ta.viewForRoute(context.Background(), route)
+	if err != nil {
+		return err
 	}
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(view)
+	return nil
 }
 
 func (ta *TaskApp) RenderNavigation() {
 	ta.mu.Lock()
 	defer ta.mu.Unlock()
 	ta.renderView(NewNavigationView(ta))
-	ta.showNavBtn.Hide()
 }
 
 func (ta *TaskApp) RenderHomeView() {
@@ -140,28 +265,240 @@ func (ta *TaskApp) RenderTaskListsView() {
 	ta.renderView(NewTaskListsView(ta))
 }
 
-func (ta *TaskApp) RenderListOfTasksView(title string, taskList *TaskList, opts ...ModelQueryOpt) {
+func (ta *TaskApp) RenderTaskListTasksView(taskList TaskList) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(newTaskListTasksView(ta, taskList))
+}
+
+// ReplaceWithTaskListTasksView shows a list's tasks in place of the active view, for leaving a form once it is done.
+func (ta *TaskApp) ReplaceWithTaskListTasksView(taskList TaskList) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.replaceView(newTaskListTasksView(ta, taskList))
+}
+
+func (ta *TaskApp) RenderTodaysTasksView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(newTodaysTasksView(ta))
+}
+
+func (ta *TaskApp) RenderStatusTasksView(name string) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(newStatusTasksView(ta, name))
+}
+
+func (ta *TaskApp) RenderDayTasksView(day time.Time) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(newDayTasksView(ta, day))
+}
+
+func (ta *TaskApp) RenderCalendarView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewCalendarView(ta, time.Now()))
+}
+
+func (ta *TaskApp) RenderAgendaView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewAgendaView(ta, time.Now()))
+}
+
+func (ta *TaskApp) RenderTagTasksView(tag Tag) {
 	ta.mu.Lock()
 	defer ta.mu.Unlock()
-	ta.renderView(NewListOfTasksView(ta, title, taskList, opts...))
+	ta.renderView(newTagTasksView(ta, tag))
 }
 
-func (ta *TaskApp) RenderMutateTaskView(task *Task, taskList *TaskList, onDelete func()) {
+func (ta *TaskApp) RenderMutateTaskView(task *Task, taskList *TaskList) {
 	ta.mu.Lock()
 	defer ta.mu.Unlock()
-	ta.renderView(NewMutateTaskView(ta, task, taskList, onDelete))
+	ta.renderView(NewMutateTaskView(ta, task, taskList))
 }
 
-func (ta *TaskApp) RenderTaskView(task Task, onDelete func()) {
+func (ta *TaskApp) RenderTaskView(task Task) {
 	ta.mu.Lock()
 	defer ta.mu.Unlock()
-	ta.renderView(NewTaskView(ta, task, onDelete))
+	ta.renderView(NewTaskView(ta, task))
 }
 
-func (ta *TaskApp) RenderTaskListView(taskList TaskList, onDelete func()) {
+func (ta *TaskApp) RenderTaskListView(taskList TaskList) {
 	ta.mu.Lock()
 	defer ta.mu.Unlock()
-	ta.renderView(NewTaskListView(ta, taskList, onDelete))
+	ta.renderView(NewTaskListView(ta, taskList))
+}
+
+func (ta *TaskApp) RenderTagsView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewTagsView(ta))
+}
+
+func (ta *TaskApp) RenderTrashView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewTrashView(ta))
+}
+
+func (ta *TaskApp) RenderSearchView(query, filter string) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewSearchView(ta, query, filter))
+}
+
+func (ta *TaskApp) RenderDataView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewDataView(ta))
+}
+
+func (ta *TaskApp) RenderCSVImportView(raw []byte, taskList TaskList) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewCSVImportView(ta, raw, taskList))
+}
+
+func (ta *TaskApp) RenderBackupsView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewBackupsView(ta))
+}
+
+func (ta *TaskApp) RenderSettingsView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.renderView(NewSettingsView(ta))
+}
+
+// RefreshView re-renders the active view, picking up any changes made to the database underneath it.
+func (ta *TaskApp) RefreshView() {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	if ta.activeView == nil {
+		return
+	}
+	ta.activeView.Background()
+	ta.showView(ta.activeView)
+}
+
+// applyTheme redraws the app after the theme or its variant changes.  Widgets follow the theme by themselves, but the
+// views color their text when built, so the active view is built again.
+func (ta *TaskApp) applyTheme() {
+	ta.background.FillColor = theme.Color(theme.ColorNameBackground)
+	ta.background.Refresh()
+	ta.RefreshView()
+}
+
+// RecordUndo remembers how to revert a mutation that was just made and offers to undo it.
+func (ta *TaskApp) RecordUndo(description string, undo UndoFunc) {
+	ta.recordUndo(undoAction{description: description, undo: undo})
+}
+
+// RecordUndoLeaving is RecordUndo for creating what route shows, so that undoing it returns from route if it is being
+// shown rather than showing something that no longer exists.
+func (ta *TaskApp) RecordUndoLeaving(description, route string, undo UndoFunc) {
+	ta.recordUndo(undoAction{description: description, undo: undo, leave: route})
+}
+
+func (ta *TaskApp) recordUndo(action undoAction) {
+	ta.undo.push(action)
+
+	gen := ta.undoBarGen.Add(1)
+	ta.undoLabel.SetText(action.description)
+	ta.undoBar.Show()
+	time.AfterFunc(5*time.Second, func() {
+		fyne.Do(func() {
+			if ta.undoBarGen.Load() == gen {
+				ta.undoBar.Hide()
+			}
+		})
+	})
+}
+
+// Undo reverts the most recently recorded mutation.
+func (ta *TaskApp) Undo() {
+	ta.undoBarGen.Add(1)
+	ta.undoBar.Hide()
+
+	action, ok := ta.undo.Pop()
+	if !ok {
+		return
+	}
+
+	log.Debug("Undoing action", "action", action.description)
+
+	if err := ta.DB().Transaction(action.undo); err != nil {
+		// put it back so retrying undoes the same action.
+		ta.undo.push(action)
+		ta.ReportError(fmt.Sprintf("Error undoing %q", action.description), err, ta.Undo)
+		return
+	}
+
+	if action.leave != "" && routeUnder(ta.ActiveRoute(), action.leave) {
+		ta.BackFrom(action.leave)
+		return
+	}
+	ta.RefreshView()
+}
+
+// RestoreBackup replaces the database with a backup.  Recorded undo actions and the navigation history refer to rows
+// of the replaced database, so they are forgotten.
+func (ta *TaskApp) RestoreBackup(path string) error {
+	ta.forgetData()
+	return ta.backups.Restore(context.Background(), ta.db, path)
+}
+
+// forgetData drops the undo stack and view history when every row is being replaced, as both may refer to rows that
+// no longer exist.
+func (ta *TaskApp) forgetData() {
+	ta.undoBarGen.Add(1)
+	ta.undoBar.Hide()
+	ta.undo.Clear()
+
+	ta.mu.Lock()
+	ta.history = nil
+	ta.mu.Unlock()
+}
+
+// ReportError logs a failure and shows it to the user in a dialog, leaving the current view and any unsaved input in
+// place.  When retry is not nil the dialog offers to try again.
+func (ta *TaskApp) ReportError(msg string, err error, retry func()) {
+	log.Error(msg, "err", err)
+
+	text := widget.NewLabel(fmt.Sprintf("%s: %v", msg, err))
+	text.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+	if retry == nil {
+		d = dialog.NewCustom("Error", "Dismiss", text, ta.window)
+	} else {
+		d = dialog.NewCustomConfirm("Error", "Retry", "Dismiss", text, func(ok bool) {
+			if ok {
+				retry()
+			}
+		}, ta.window)
+	}
+	d.Resize(fyne.NewSize(320, 200))
+	d.Show()
+}
+
+// loadFailed reports a view failing to load its data and returns what the view shows instead, offering to reload.
+func (ta *TaskApp) loadFailed(msg string, err error) fyne.CanvasObject {
+	ta.ReportError(msg, err, ta.RefreshView)
+
+	text := widget.NewLabel(msg)
+	text.Wrapping = fyne.TextWrapWord
+	text.Alignment = fyne.TextAlignCenter
+
+	return container.NewCenter(container.NewVBox(
+		widget.NewIcon(theme.ErrorIcon()),
+		text,
+		widget.NewButtonWithIcon("Retry", theme.ViewRefreshIcon(), ta.RefreshView),
+	))
 }
 
 func (ta *TaskApp) Container() *fyne.Container {
@@ -171,5 +508,5 @@ func (ta *TaskApp) Container() *fyne.Container {
 }
 
 func (ta *TaskApp) DB() *gorm.DB {
-	return ta.db
+	return ta.db.Get()
 }
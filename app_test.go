@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
+)
+
+var errTestWrite = errors.New("write failed")
+
+// newTestTaskApp opens an empty database in a temporary directory and shows a TaskApp for it in a test window.
+func newTestTaskApp(t *testing.T) (*TaskApp, *gorm.DB) {
+	t.Helper()
+	log = slog.Default()
+
+	db, err := openDB(filepath.Join(t.TempDir(), "test.db"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tryCloseDB(db) })
+
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+	w := test.NewWindow(nil)
+	t.Cleanup(w.Close)
+
+	ta := newTaskApp(a, w, NewDBRef(db), BackupConfig{})
+	w.SetContent(ta.Container())
+	return ta, db
+}
+
+// failWrites makes every insert, update and delete on db fail, while reads carry on working.
+func failWrites(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	fail := func(tx *gorm.DB) {
+		_ = tx.AddError(errTestWrite)
+	}
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("test:fail_create", fail),
+		cb.Update().Before("gorm:update").Register("test:fail_update", fail),
+		cb.Delete().Before("gorm:delete").Register("test:fail_delete", fail),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func createTestTask(t *testing.T, db *gorm.DB, task Task) Task {
+	t.Helper()
+	task.SortOrder = GetNextTaskOrderNum()
+	if err := CreateTask(db, &task); err != nil {
+		t.Fatal(err)
+	}
+	return task
+}
+
+func createTestTaskList(t *testing.T, db *gorm.DB, label string) TaskList {
+	t.Helper()
+	taskList := TaskList{Label: label}
+	if err := db.Create(&taskList).Error; err != nil {
+		t.Fatal(err)
+	}
+	return taskList
+}
+
+// findButton returns the first button labelled text in obj, searching containers and scrolls.
+func findButton(obj fyne.CanvasObject, text string) *widget.Button {
+	switch o := obj.(type) {
+	case *widget.Button:
+		if o.Text == text {
+			return o
+		}
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			if btn := findButton(child, text); btn != nil {
+				return btn
+			}
+		}
+	case *container.Scroll:
+		return findButton(o.Content, text)
+	}
+	return nil
+}
+
+// assertErrorShown checks that a failure was reported to the user rather than swallowed.
+func assertErrorShown(t *testing.T, ta *TaskApp) {
+	t.Helper()
+	if ta.window.Canvas().Overlays().Top() == nil {
+		t.Error("expected an error dialog")
+	}
+}
+
+func TestGetListForTaskDBError(t *testing.T) {
+	_, db := newTestTaskApp(t)
+	taskList := createTestTaskList(t, db, "Chores")
+	task := createTestTask(t, db, Task{Label: "Dishes", TaskListID: sql.Null[int]{V: int(taskList.ID), Valid: true}})
+	tryCloseDB(db)
+
+	got, err := GetListForTask(context.Background(), db, task)
+	if err == nil {
+		t.Fatal("expected an error from a closed database")
+	}
+	if got != nil {
+		t.Errorf("expected no task list, got %+v", got)
+	}
+}
+
+func TestTaskStatusSwitcherButtonDBError(t *testing.T) {
+	ta, db := newTestTaskApp(t)
+	task := createTestTask(t, db, Task{Label: "Dishes", Status: TaskStatusTodo})
+	failWrites(t, db)
+
+	btn := newTaskStatusSwitcherButton(ta, &task)
+	test.Tap(btn)
+
+	if task.Status != TaskStatusTodo {
+		t.Errorf("status changed to %d despite the update failing", task.Status)
+	}
+	if ta.undo.Len() != 0 {
+		t.Error("failed update was recorded for undo")
+	}
+	assertErrorShown(t, ta)
+}
+
+func TestTaskPrioritySwitcherButtonDBError(t *testing.T) {
+	ta, db := newTestTaskApp(t)
+	task := createTestTask(t, db, Task{Label: "Dishes", UserPriority: TaskPriorityNumber(TaskPriorityLow)})
+	failWrites(t, db)
+
+	btn := newTaskPrioritySwitcherButton(ta, &task)
+	test.Tap(btn)
+
+	if task.UserPriority != TaskPriorityNumber(TaskPriorityLow) {
+		t.Errorf("priority changed to %d despite the update failing", task.UserPriority)
+	}
+	if ta.undo.Len() != 0 {
+		t.Error("failed update was recorded for undo")
+	}
+	assertErrorShown(t, ta)
+}
+
+func TestMutateTaskViewSaveDBError(t *testing.T) {
+	tests := []struct {
+		name string
+		edit bool
+	}{
+		{name: "create"},
+		{name: "edit", edit: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ta, db := newTestTaskApp(t)
+			taskList := createTestTaskList(t, db, "Chores")
+			var task *Task
+			if tt.edit {
+				created := createTestTask(t, db, Task{Label: "Dishes", TaskListID: sql.Null[int]{V: int(taskList.ID), Valid: true}})
+				task = &created
+			}
+			ta.RenderMutateTaskView(task, &taskList)
+			view := ta.activeView
+			failWrites(t, db)
+
+			save := findButton(ta.contentWrapper, "Save")
+			if save == nil {
+				t.Fatal("no save button")
+			}
+			if !tt.edit {
+				setFirstEntry(t, ta.contentWrapper, "Laundry")
+			}
+			test.Tap(save)
+
+			if ta.activeView != view {
+				t.Error("left the form despite the save failing")
+			}
+			if tt.edit && task.Label != "Dishes" {
+				t.Errorf("task label changed to %q despite the save failing", task.Label)
+			}
+			if ta.undo.Len() != 0 {
+				t.Error("failed save was recorded for undo")
+			}
+			assertErrorShown(t, ta)
+		})
+	}
+}
+
+func TestMutateTaskListViewSaveDBError(t *testing.T) {
+	tests := []struct {
+		name string
+		edit bool
+	}{
+		{name: "create"},
+		{name: "edit", edit: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ta, db := newTestTaskApp(t)
+			var taskList *TaskList
+			if tt.edit {
+				created := createTestTaskList(t, db, "Chores")
+				taskList = &created
+			}
+			ta.RenderMutateTaskListView(taskList)
+			view := ta.activeView
+			failWrites(t, db)
+
+			if !tt.edit {
+				setFirstEntry(t, ta.contentWrapper, "Errands")
+			}
+			save := findButton(ta.contentWrapper, "Save")
+			if save == nil {
+				t.Fatal("no save button")
+			}
+			test.Tap(save)
+
+			if ta.activeView != view {
+				t.Error("left the form despite the save failing")
+			}
+			if tt.edit && taskList.Label != "Chores" {
+				t.Errorf("task list label changed to %q despite the save failing", taskList.Label)
+			}
+			if ta.undo.Len() != 0 {
+				t.Error("failed save was recorded for undo")
+			}
+			assertErrorShown(t, ta)
+		})
+	}
+}
+
+func TestMutateTaskViewLoadDBError(t *testing.T) {
+	ta, db := newTestTaskApp(t)
+	tryCloseDB(db)
+
+	ta.RenderMutateTaskView(nil, nil)
+
+	if findButton(ta.contentWrapper, "Save") != nil {
+		t.Error("form shown despite its task lists failing to load")
+	}
+	assertErrorShown(t, ta)
+}
+
+// setFirstEntry types text into the first entry in obj, which in the forms is the required name or title.
+func setFirstEntry(t *testing.T, obj fyne.CanvasObject, text string) {
+	t.Helper()
+	if e := findEntry(obj); e != nil {
+		e.SetText(text)
+		return
+	}
+	t.Fatal("no entry")
+}
+
+func findEntry(obj fyne.CanvasObject) *widget.Entry {
+	switch o := obj.(type) {
+	case *widget.Entry:
+		return o
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			if e := findEntry(child); e != nil {
+				return e
+			}
+		}
+	case *container.Scroll:
+		return findEntry(o.Content)
+	}
+	return nil
+}
+
+func TestUndoTaskEditRestoresTaskView(t *testing.T) {
+	ta, db := newTestTaskApp(t)
+	task := createTestTask(t, db, Task{Label: "Dishes"})
+	ta.RenderTaskView(task)
+	view := ta.activeView.(*TaskView)
+
+	test.Tap(findButton(ta.contentWrapper, "Edit"))
+	setFirstEntry(t, ta.contentWrapper, "Laundry")
+	test.Tap(findButton(ta.contentWrapper, "Save"))
+	if view.task.Label != "Laundry" {
+		t.Fatalf("task view shows %q after the edit", view.task.Label)
+	}
+
+	ta.Undo()
+
+	if view.task.Label != "Dishes" {
+		t.Errorf("task view shows %q after undoing the edit", view.task.Label)
+	}
+}
+
+func TestUndoTaskListEditRestoresTaskListView(t *testing.T) {
+	ta, db := newTestTaskApp(t)
+	taskList := createTestTaskList(t, db, "Chores")
+	ta.RenderTaskListView(taskList)
+	view := ta.activeView.(*TaskListView)
+
+	test.Tap(findButton(ta.contentWrapper, "Edit"))
+	setFirstEntry(t, ta.contentWrapper, "Errands")
+	test.Tap(findButton(ta.contentWrapper, "Save"))
+	if view.taskList.Label != "Errands" {
+		t.Fatalf("task list view shows %q after the edit", view.taskList.Label)
+	}
+
+	ta.Undo()
+
+	if view.taskList.Label != "Chores" {
+		t.Errorf("task list view shows %q after undoing the edit", view.taskList.Label)
+	}
+}
+
+func TestUndoTaskListCreateLeavesItsView(t *testing.T) {
+	ta, _ := newTestTaskApp(t)
+	ta.RenderHomeView()
+	ta.RenderMutateTaskListView(nil)
+
+	setFirstEntry(t, ta.contentWrapper, "Errands")
+	test.Tap(findButton(ta.contentWrapper, "Save"))
+	if _, ok := ta.activeView.(*ListOfTasksView); !ok {
+		t.Fatalf("showing %T after creating a list", ta.activeView)
+	}
+
+	ta.Undo()
+
+	if _, ok := ta.activeView.(*HomeView); !ok {
+		t.Errorf("showing %T after undoing the list's creation", ta.activeView)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	backupTimeFormat = "20060102-150405"
+	backupExt        = ".db"
+)
+
+// BackupConfig says where copies of the database are written and how many are kept.
+type BackupConfig struct {
+	DBFile   string
+	Dir      string
+	Keep     int
+	LogDebug bool
+}
+
+// Enabled returns false when backups are turned off.
+func (c BackupConfig) Enabled() bool {
+	return c.Keep > 0 && c.Dir != ""
+}
+
+// prefix is the start of every backup file name, the database file name without its extension.
+func (c BackupConfig) prefix() string {
+	base := filepath.Base(c.DBFile)
+	return strings.TrimSuffix(base, filepath.Ext(base)) + "-"
+}
+
+// BackupInfo describes a backup file.
+type BackupInfo struct {
+	Path string
+	Time time.Time
+	Size int64
+}
+
+// BackupStats are read from inside a backup, to help pick one to restore.
+type BackupStats struct {
+	SchemaVersion uint
+	TaskLists     int64
+	Tasks         int64
+}
+
+// Backup writes a consistent copy of db into the backup directory using VACUUM INTO, which is safe while the database
+// is in use, then removes the oldest backups beyond Keep.
+func (c BackupConfig) Backup(ctx context.Context, db *gorm.DB) (string, error) {
+	if !c.Enabled() {
+		return "", errors.New("backups are disabled")
+	}
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return "", fmt.Errorf("error creating backup directory: %w", err)
+	}
+
+	path := filepath.Join(c.Dir, c.prefix()+time.Now().Format(backupTimeFormat)+backupExt)
+	if _, err := os.Stat(path); err == nil {
+		// already backed up this second.
+		return path, nil
+	}
+
+	log.Debug("Backing up database", "path", path)
+	if err := db.WithContext(ctx).Exec("VACUUM INTO ?", path).Error; err != nil {
+		return "", fmt.Errorf("error backing up database: %w", err)
+	}
+
+	if err := c.rotate(); err != nil {
+		log.Warn("Error removing old backups", "err", err)
+	}
+	return path, nil
+}
+
+// List returns the backups in the backup directory, newest first.
+func (c BackupConfig) List() ([]BackupInfo, error) {
+	prefix := c.prefix()
+	entries, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading backup directory: %w", err)
+	}
+
+	var out []BackupInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, backupExt) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), backupExt)
+		t, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		out = append(out, BackupInfo{Path: filepath.Join(c.Dir, name), Time: t, Size: info.Size()})
+	}
+
+	slices.SortFunc(out, func(a, b BackupInfo) int {
+		return b.Time.Compare(a.Time)
+	})
+	return out, nil
+}
+
+func (c BackupConfig) rotate() error {
+	backups, err := c.List()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, b := range backups[min(len(backups), c.Keep):] {
+		log.Debug("Removing old backup", "path", b.Path)
+		if err := os.Remove(b.Path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// InspectBackup checks a backup is intact and counts the lists and tasks in it.
+func InspectBackup(path string) (BackupStats, error) {
+	var stats BackupStats
+
+	if _, err := os.Stat(path); err != nil {
+		return stats, err
+	}
+	db, err := connectDB(path, false)
+	if err != nil {
+		return stats, err
+	}
+	defer tryCloseDB(db)
+
+	var check string
+	if err = db.Raw("PRAGMA quick_check").Scan(&check).Error; err != nil {
+		return stats, fmt.Errorf("error checking backup: %w", err)
+	}
+	if check != "ok" {
+		return stats, fmt.Errorf("backup is damaged: %s", check)
+	}
+
+	if stats.SchemaVersion, err = SchemaVersion(db); err != nil {
+		return stats, err
+	}
+	if err = db.Raw("SELECT count(*) FROM task_lists WHERE deleted_at IS NULL").Scan(&stats.TaskLists).Error; err != nil {
+		return stats, fmt.Errorf("error counting task lists: %w", err)
+	}
+	if err = db.Raw("SELECT count(*) FROM tasks WHERE deleted_at IS NULL").Scan(&stats.Tasks).Error; err != nil {
+		return stats, fmt.Errorf("error counting tasks: %w", err)
+	}
+	return stats, nil
+}
+
+// Restore replaces the active database with a backup and swaps ref over to it.  The current database is backed up
+// first, so a restore can itself be undone by restoring that copy.  The backup is copied next to the database file and
+// renamed over it, so the database file is never left half-written.  Work in progress against the old database, such
+// as an API request, fails once it is closed.
+func (c BackupConfig) Restore(ctx context.Context, ref *DBRef, path string) error {
+	stats, err := InspectBackup(path)
+	if err != nil {
+		return err
+	}
+	if latest := LatestSchemaVersion(); stats.SchemaVersion > latest {
+		return fmt.Errorf("backup schema version %d is newer than this app supports (%d)", stats.SchemaVersion, latest)
+	}
+
+	tmpFile := c.DBFile + ".restore"
+	if err = copyFile(path, tmpFile); err != nil {
+		_ = os.Remove(tmpFile)
+		return fmt.Errorf("error copying backup: %w", err)
+	}
+
+	// after the copy, as rotation may remove the backup being restored.
+	if _, err = c.Backup(ctx, ref.Get()); err != nil {
+		_ = os.Remove(tmpFile)
+		return fmt.Errorf("error backing up current database before restoring: %w", err)
+	}
+
+	log.Info("Restoring database backup", "path", path)
+
+	// nothing may hold the old file open while it is replaced.
+	tryCloseDB(ref.Get())
+
+	if err = os.Rename(tmpFile, c.DBFile); err != nil {
+		_ = os.Remove(tmpFile)
+		err = fmt.Errorf("error replacing database file: %w", err)
+	}
+	// the old database was closed cleanly, anything left beside it belongs to it and not to the restored file.
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		_ = os.Remove(c.DBFile + suffix)
+	}
+
+	// reopen even when the rename failed, so the app carries on with the database it had.
+	db, openErr := openDB(c.DBFile, c.LogDebug)
+	if openErr != nil {
+		return errors.Join(err, fmt.Errorf("error opening restored database: %w", openErr))
+	}
+	ref.Swap(db)
+	return err
+}
+
+// ScheduleBackups backs up the database every interval until ctx is done.
+func (c BackupConfig) ScheduleBackups(ctx context.Context, ref *DBRef, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if path, err := c.Backup(ctx, ref.Get()); err != nil {
+				log.Error("Error running scheduled backup", "err", err)
+			} else {
+				log.Debug("Scheduled backup complete", "path", path)
+			}
+		}
+	}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err = out.Sync(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+)
+
+const (
+	cliFormatTable = "table"
+	cliFormatJSON  = "json"
+)
+
+type cliCommand struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error
+}
+
+var cliCommands = []cliCommand{
+	{name: "add", summary: "Create a task", run: cliAdd},
+	{name: "list", summary: "List tasks", run: cliList},
+	{name: "done", summary: "Mark tasks as done", run: cliDone},
+	{name: "lists", summary: "List task lists", run: cliLists},
+}
+
+func printCLIUsage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range cliCommands {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.summary)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintln(w, "Run \"<command> -h\" for command options.")
+}
+
+// runCLI executes a single headless command against the database, returning the process exit code.
+func runCLI(ctx context.Context, db *gorm.DB, args []string, out, errOut io.Writer) int {
+	for _, cmd := range cliCommands {
+		if cmd.name != args[0] {
+			continue
+		}
+		if err := cmd.run(ctx, db, args[1:], out); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 0
+			}
+			_, _ = fmt.Fprintf(errOut, "%s: %v\n", cmd.name, err)
+			return 1
+		}
+		return 0
+	}
+	_, _ = fmt.Fprintf(errOut, "unknown command %q\n", args[0])
+	printCLIUsage(errOut)
+	return 2
+}
+
+func newCLIFlagSet(name string, out io.Writer) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(out)
+	format := fs.String("format", cliFormatTable, "Output format, table or json")
+	return fs, format
+}
+
+func checkCLIFormat(format string) error {
+	if format != cliFormatTable && format != cliFormatJSON {
+		return fmt.Errorf("unknown format %q, expected %s or %s", format, cliFormatTable, cliFormatJSON)
+	}
+	return nil
+}
+
+// findTaskListByRef finds a task list by ID, or by case-insensitive label if ref isn't a number.
+func findTaskListByRef(ctx context.Context, db *gorm.DB, ref string) (*TaskList, error) {
+	var opt ModelQueryOpt
+	if id, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		opt = func(db *gorm.DB) *gorm.DB {
+			return db.Where("id = ?", id)
+		}
+	} else {
+		opt = func(db *gorm.DB) *gorm.DB {
+			return db.Where("lower(label) = lower(?)", ref)
+		}
+	}
+	taskList, err := FindOneModel[TaskList](ctx, db, opt)
+	if err != nil {
+		return nil, fmt.Errorf("error finding task list %q: %w", ref, err)
+	}
+	if taskList == nil {
+		return nil, fmt.Errorf("task list %q not found", ref)
+	}
+	return taskList, nil
+}
+
+func writeCLITasks(w io.Writer, format string, tasks []Task) error {
+	out := make([]TaskJSON, len(tasks))
+	for i := range tasks {
+		out[i] = NewTaskJSON(tasks[i])
+	}
+	if format == cliFormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tSTATUS\tPRIORITY\tDUE\tLIST\tLABEL")
+	for _, t := range out {
+		due := "-"
+		if t.DueDate != nil {
+			due = t.DueDate.Format("2006-01-02 15:04")
+		}
+		list := t.List
+		if list == "" {
+			list = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", t.ID, t.Status, t.Priority, due, list, t.Label)
+	}
+	return tw.Flush()
+}
+
+func writeCLITaskLists(w io.Writer, format string, taskLists []TaskList, taskCounts []int64) error {
+	if format == cliFormatJSON {
+		type taskListWithCount struct {
+			TaskListJSON
+			TaskCount int64 `json:"task_count"`
+		}
+		out := make([]taskListWithCount, len(taskLists))
+		for i := range taskLists {
+			out[i] = taskListWithCount{TaskListJSON: NewTaskListJSON(taskLists[i]), TaskCount: taskCounts[i]}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tDATE\tTASKS\tLABEL")
+	for i, tl := range taskLists {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%d\t%s\n", tl.ID, tl.Date.Format("2006-01-02"), taskCounts[i], tl.Label)
+	}
+	return tw.Flush()
+}
+
+func loadCLITask(ctx context.Context, db *gorm.DB, id uint) (*Task, error) {
+	task, err := FindOneModel[Task](ctx, db, WithPreload("TaskList"), WithPreload("Tags"), func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error finding task %d: %w", id, err)
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task %d not found", id)
+	}
+	return task, nil
+}
+
+func cliAdd(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	fs, format := newCLIFlagSet("add", out)
+	listRef := fs.String("list", "", "ID or name of the task list to add the task to")
+	description := fs.String("description", "", "Markdown description")
+	priority := fs.String("priority", TaskPriorityHigh, "Priority, one of lowest, low, neutral, high, highest")
+	status := fs.String("status", TaskStatusTitleTodo, "Status, one of todo, in-progress, done, skip")
+	due := fs.String("due", "now", "Due date, e.g. 2006-01-02 15:04, today or tomorrow")
+	repeat := fs.String("repeat", "", "RRULE-style recurrence, e.g. FREQ=WEEKLY;BYDAY=MO")
+	tags := fs.String("tags", "", "Comma separated tags")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "Usage: add [options] <label...>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkCLIFormat(*format); err != nil {
+		return err
+	}
+
+	label := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if label == "" {
+		return errors.New("a task label is required")
+	}
+
+	task := Task{
+		Label:       label,
+		Description: *description,
+		SortOrder:   GetNextTaskOrderNum(),
+	}
+
+	var err error
+	if task.UserPriority, err = ParseTaskPriority(*priority); err != nil {
+		return err
+	}
+	if task.Status, err = ParseTaskStatus(*status); err != nil {
+		return err
+	}
+	if task.DueDate, err = ParseDateTime(*due); err != nil {
+		return err
+	}
+	if *repeat != "" {
+		rule, err := ParseTaskRecurrence(*repeat)
+		if err != nil {
+			return err
+		}
+		task.Recurrence = rule.String()
+	}
+	if *listRef != "" {
+		taskList, err := findTaskListByRef(ctx, db, *listRef)
+		if err != nil {
+			return err
+		}
+		task.TaskListID = sql.Null[int]{V: int(taskList.ID), Valid: true}
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := CreateTask(tx, &task); err != nil {
+			return fmt.Errorf("error creating task: %w", err)
+		}
+		return SetTaskTags(tx, task.ID, ParseTagLabels(*tags))
+	})
+	if err != nil {
+		return err
+	}
+
+	created, err := loadCLITask(ctx, db, task.ID)
+	if err != nil {
+		return err
+	}
+	return writeCLITasks(out, *format, []Task{*created})
+}
+
+func cliList(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	fs, format := newCLIFlagSet("list", out)
+	today := fs.Bool("today", false, "Only list tasks due today")
+	listRef := fs.String("list", "", "Only list tasks in this task list, by ID or name")
+	status := fs.String("status", "", "Only list tasks with this status")
+	tag := fs.String("tag", "", "Only list tasks with this tag")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkCLIFormat(*format); err != nil {
+		return err
+	}
+
+	opts := []ModelQueryOpt{WithPreload("Tags")}
+	if *today {
+		opts = append(opts, todaysTasksModelQueryOpt())
+	} else {
+		opts = append(opts, WithPreload("TaskList"), WithSort("due_date asc"), WithSort("id asc"))
+	}
+	if *listRef != "" {
+		taskList, err := findTaskListByRef(ctx, db, *listRef)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, func(db *gorm.DB) *gorm.DB {
+			return db.Where("task_list_id = ?", taskList.ID)
+		})
+	}
+	if *status != "" {
+		statusNum, err := ParseTaskStatus(*status)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", statusNum)
+		})
+	}
+	if *tag != "" {
+		tags, err := FindModel[Tag](ctx, db, func(db *gorm.DB) *gorm.DB {
+			return db.Where("lower(label) = lower(?)", strings.TrimPrefix(*tag, "#"))
+		})
+		if err != nil {
+			return fmt.Errorf("error finding tag %q: %w", *tag, err)
+		}
+		if len(tags) == 0 {
+			return fmt.Errorf("tag %q not found", *tag)
+		}
+		opts = append(opts, tagTasksModelQueryOpt(tags[0].ID))
+	}
+
+	tasks, err := FindModel[Task](ctx, db, opts...)
+	if err != nil {
+		return fmt.Errorf("error finding tasks: %w", err)
+	}
+	return writeCLITasks(out, *format, tasks)
+}
+
+func cliDone(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	fs, format := newCLIFlagSet("done", out)
+	skip := fs.Bool("skip", false, "Mark the tasks as skipped instead of done")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "Usage: done [options] <id...>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkCLIFormat(*format); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("at least one task ID is required")
+	}
+
+	status := TaskStatusDone
+	if *skip {
+		status = TaskStatusSkip
+	}
+
+	updated := make([]Task, 0, fs.NArg())
+	for _, arg := range fs.Args() {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid task ID %q", arg)
+		}
+		task, err := loadCLITask(ctx, db, uint(id))
+		if err != nil {
+			return err
+		}
+		next, err := UpdateTaskStatus(db.WithContext(ctx), task, status)
+		if err != nil {
+			return err
+		}
+		updated = append(updated, *task)
+		if next != nil {
+			if next, err = loadCLITask(ctx, db, next.ID); err != nil {
+				return err
+			}
+			updated = append(updated, *next)
+		}
+	}
+
+	return writeCLITasks(out, *format, updated)
+}
+
+func cliLists(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	fs, format := newCLIFlagSet("lists", out)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkCLIFormat(*format); err != nil {
+		return err
+	}
+
+	taskLists, err := FindModel[TaskList](ctx, db, WithSort("date asc"))
+	if err != nil {
+		return fmt.Errorf("error finding task lists: %w", err)
+	}
+
+	taskCounts := make([]int64, len(taskLists))
+	for i := range taskLists {
+		taskCounts[i], err = CountModel[Task](ctx, db, func(db *gorm.DB) *gorm.DB {
+			return db.Where("task_list_id = ?", taskLists[i].ID)
+		})
+		if err != nil {
+			return fmt.Errorf("error counting tasks in task list %d: %w", taskLists[i].ID, err)
+		}
+	}
+
+	return writeCLITaskLists(out, *format, taskLists, taskCounts)
+}
+
+// openCLIInput opens path for reading, "-" being stdin.
+func openCLIInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// createCLIOutput creates path for writing, "-" being stdout.
+func createCLIOutput(path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// dataFlags holds the file import and export options given on the command line.
+type dataFlags struct {
+	exportJSON    string
+	exportDeleted bool
+	importJSON    string
+	importMode    string
+	exportTodoTxt string
+	importTodoTxt string
+	todoTxtList   string
+}
+
+func (f dataFlags) requested() bool {
+	return f.exportJSON != "" || f.importJSON != "" || f.exportTodoTxt != "" || f.importTodoTxt != ""
+}
+
+// run performs any requested import before any requested export, so both may be combined to convert a file.
+func (f dataFlags) run(ctx context.Context, db *gorm.DB) error {
+	if f.importJSON != "" {
+		in, err := openCLIInput(f.importJSON)
+		if err != nil {
+			return err
+		}
+		result, err := ImportJSON(ctx, db, in, f.importMode)
+		_ = in.Close()
+		if err != nil {
+			return err
+		}
+		log.Info("Imported JSON", "file", f.importJSON, "mode", f.importMode, "lists", result.TaskLists, "tasks", result.Tasks)
+	}
+
+	var todoTxtList *TaskList
+	if f.todoTxtList != "" {
+		var err error
+		if todoTxtList, err = findTaskListByRef(ctx, db, f.todoTxtList); err != nil {
+			return err
+		}
+	}
+
+	if f.importTodoTxt != "" {
+		in, err := openCLIInput(f.importTodoTxt)
+		if err != nil {
+			return err
+		}
+		created, err := ImportTodoTxt(ctx, db, in, todoTxtList)
+		_ = in.Close()
+		if err != nil {
+			return err
+		}
+		log.Info("Imported todo.txt", "file", f.importTodoTxt, "tasks", created)
+	}
+
+	if f.exportJSON != "" {
+		out, err := createCLIOutput(f.exportJSON)
+		if err != nil {
+			return err
+		}
+		err = ExportJSON(ctx, db, out, f.exportDeleted)
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		log.Debug("Exported JSON", "file", f.exportJSON)
+	}
+
+	if f.exportTodoTxt != "" {
+		opts := []ModelQueryOpt{WithSort("due_date asc"), WithSort("id asc")}
+		if todoTxtList != nil {
+			opts = []ModelQueryOpt{taskListTasksModelQueryOpt(todoTxtList.ID)}
+		}
+		out, err := createCLIOutput(f.exportTodoTxt)
+		if err != nil {
+			return err
+		}
+		err = ExportTodoTxt(ctx, db, out, opts...)
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		log.Debug("Exported todo.txt", "file", f.exportTodoTxt)
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	CSVFieldIgnore      = "(ignore)"
+	CSVFieldLabel       = "Label"
+	CSVFieldDescription = "Description"
+	CSVFieldStatus      = "Status"
+	CSVFieldPriority    = "Priority"
+	CSVFieldDueDate     = "Due date"
+	CSVFieldTags        = "Tags"
+)
+
+var (
+	csvExportHeader = []string{
+		"id",
+		"list",
+		"label",
+		"status",
+		"priority",
+		"due_date",
+		"created_at",
+		"updated_at",
+		"description",
+	}
+
+	// CSVFields are the Task fields a CSV column can be imported into.
+	CSVFields = []string{
+		CSVFieldIgnore,
+		CSVFieldLabel,
+		CSVFieldDescription,
+		CSVFieldStatus,
+		CSVFieldPriority,
+		CSVFieldDueDate,
+		CSVFieldTags,
+	}
+
+	// csvFieldAliases are header names, compared case-insensitively, recognised as each field by GuessCSVMapping.
+	csvFieldAliases = map[string][]string{
+		CSVFieldLabel:       {"label", "title", "name", "task", "summary", "subject"},
+		CSVFieldDescription: {"description", "notes", "note", "details", "body"},
+		CSVFieldStatus:      {"status", "state"},
+		CSVFieldPriority:    {"priority", "importance"},
+		CSVFieldDueDate:     {"due_date", "due date", "due", "deadline", "date"},
+		CSVFieldTags:        {"tags", "tag", "labels", "categories"},
+	}
+)
+
+// ExportCSV writes the tasks selected by opts to w as CSV with a header row.
+func ExportCSV(ctx context.Context, db *gorm.DB, w io.Writer, opts ...ModelQueryOpt) error {
+	tasks, err := FindModel[Task](ctx, db, append(opts, WithPreload("TaskList"))...)
+	if err != nil {
+		return fmt.Errorf("error reading tasks: %w", err)
+	}
+
+	cw := csv.NewWriter(w)
+	if err = cw.Write(csvExportHeader); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		var listLabel, dueDate string
+		if task.TaskList != nil {
+			listLabel = task.TaskList.Label
+		}
+		if !task.DueDate.IsZero() {
+			dueDate = task.DueDate.Format(time.RFC3339)
+		}
+		err = cw.Write([]string{
+			strconv.FormatUint(uint64(task.ID), 10),
+			listLabel,
+			task.Label,
+			TaskStatusTitle(task.Status),
+			TaskPriorityName(task.UserPriority),
+			dueDate,
+			task.CreatedAt.Format(time.RFC3339),
+			task.UpdatedAt.Format(time.RFC3339),
+			task.Description,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// CSVData is a parsed CSV file awaiting import.
+type CSVData struct {
+	Header    []string
+	Rows      [][]string
+	HasHeader bool
+}
+
+// ReadCSV reads a whole CSV file.  When hasHeader is false, columns are named by position.
+func ReadCSV(r io.Reader, hasHeader bool) (*CSVData, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV file is empty")
+	}
+
+	data := CSVData{Rows: records, HasHeader: hasHeader}
+	if hasHeader {
+		data.Header = records[0]
+		data.Rows = records[1:]
+	}
+
+	width := len(data.Header)
+	for _, row := range data.Rows {
+		width = max(width, len(row))
+	}
+	for i := len(data.Header); i < width; i++ {
+		data.Header = append(data.Header, fmt.Sprintf("Column %d", i+1))
+	}
+
+	return &data, nil
+}
+
+// Column returns up to limit non-empty values from a column, for previews.
+func (d CSVData) Column(col, limit int) []string {
+	var out []string
+	for _, row := range d.Rows {
+		if len(out) == limit {
+			break
+		}
+		if col < len(row) && strings.TrimSpace(row[col]) != "" {
+			out = append(out, row[col])
+		}
+	}
+	return out
+}
+
+// GuessCSVMapping maps each header to the CSVFields entry it most likely holds, each field being used at most once.
+func GuessCSVMapping(header []string) []string {
+	mapping := make([]string, len(header))
+	for i, name := range header {
+		mapping[i] = CSVFieldIgnore
+		name = strings.ToLower(strings.TrimSpace(name))
+		for _, field := range CSVFields[1:] {
+			if slices.Contains(mapping, field) {
+				continue
+			}
+			if slices.Contains(csvFieldAliases[field], name) {
+				mapping[i] = field
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// CSVRowError is a validation error for a single CSV row.  Row is 1-based and counts the header.
+type CSVRowError struct {
+	Row int
+	Err error
+}
+
+func (e CSVRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+type CSVImportResult struct {
+	Created int
+	Errors  []CSVRowError
+}
+
+// csvRowTask builds a task from a CSV row using mapping, the field for each column.
+func csvRowTask(row []string, mapping []string) (Task, []string, error) {
+	task := Task{
+		Status:       TaskStatusTodo,
+		UserPriority: TaskPriorityNumber(TaskPriorityNeutral),
+	}
+	var tags []string
+
+	for col, field := range mapping {
+		if col >= len(row) {
+			continue
+		}
+		value := strings.TrimSpace(row[col])
+		if value == "" {
+			continue
+		}
+
+		var err error
+		switch field {
+		case CSVFieldLabel:
+			task.Label = value
+		case CSVFieldDescription:
+			task.Description = row[col]
+		case CSVFieldStatus:
+			task.Status, err = ParseTaskStatus(value)
+		case CSVFieldPriority:
+			task.UserPriority, err = ParseTaskPriority(value)
+		case CSVFieldDueDate:
+			task.DueDate, err = ParseDateTime(value)
+		case CSVFieldTags:
+			tags = ParseTagLabels(strings.NewReplacer(";", ",", "|", ",").Replace(value))
+		}
+		if err != nil {
+			return task, nil, err
+		}
+	}
+
+	if task.Label == "" {
+		return task, nil, errors.New("label is empty")
+	}
+	return task, tags, nil
+}
+
+// ImportCSV adds the rows of data to taskList, mapping column i to the CSVFields entry mapping[i].  Rows that fail
+// validation are skipped and reported in the result while the rest are imported.
+func ImportCSV(ctx context.Context, db *gorm.DB, data *CSVData, mapping []string, taskList *TaskList) (CSVImportResult, error) {
+	var result CSVImportResult
+
+	if !slices.Contains(mapping, CSVFieldLabel) {
+		return result, errors.New("a column must be mapped to the task label")
+	}
+	for _, field := range CSVFields[1:] {
+		if n := len(slices.DeleteFunc(slices.Clone(mapping), func(f string) bool { return f != field })); n > 1 {
+			return result, fmt.Errorf("%d columns are mapped to %s", n, strings.ToLower(field))
+		}
+	}
+	if taskList == nil {
+		return result, errors.New("no task list chosen to import into")
+	}
+
+	rowOffset := 1
+	if data.HasHeader {
+		rowOffset = 2
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, row := range data.Rows {
+			task, tags, err := csvRowTask(row, mapping)
+			if err != nil {
+				result.Errors = append(result.Errors, CSVRowError{Row: i + rowOffset, Err: err})
+				continue
+			}
+			task.SortOrder = GetNextTaskOrderNum()
+			task.TaskListID = sql.Null[int]{V: int(taskList.ID), Valid: true}
+			if err = CreateTask(tx, &task); err != nil {
+				return fmt.Errorf("error importing row %d: %w", i+rowOffset, err)
+			}
+			if len(tags) > 0 {
+				if err = SetTaskTags(tx, task.ID, tags); err != nil {
+					return err
+				}
+			}
+			result.Created++
+		}
+		return nil
+	})
+	if err != nil {
+		result.Created = 0
+	}
+
+	return result, err
+}
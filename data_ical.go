@@ -0,0 +1,460 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	icalProdID        = "-//IT488//Todo Today//EN"
+	icalTimeFormatUTC = "20060102T150405Z"
+	icalTimeFormat    = "20060102T150405"
+	icalDateFormat    = "20060102"
+	icalMaxLineOctets = 75
+	icalUntitledTask  = "Untitled"
+)
+
+// TaskStatusICal returns the iCalendar VTODO STATUS value for a task status.
+func TaskStatusICal(status uint) string {
+	switch status {
+	case TaskStatusInProgress:
+		return "IN-PROCESS"
+	case TaskStatusDone:
+		return "COMPLETED"
+	case TaskStatusSkip:
+		return "CANCELLED"
+
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// ParseTaskStatusICal maps a VTODO or VEVENT STATUS value to a task status.  Unknown values, including the VEVENT
+// only TENTATIVE and CONFIRMED, are treated as still to do.
+func ParseTaskStatusICal(status string) uint {
+	switch strings.ToUpper(strings.TrimSpace(status)) {
+	case "IN-PROCESS":
+		return TaskStatusInProgress
+	case "COMPLETED":
+		return TaskStatusDone
+	case "CANCELLED":
+		return TaskStatusSkip
+
+	default:
+		return TaskStatusTodo
+	}
+}
+
+// TaskPriorityICal returns the iCalendar PRIORITY value, 1 being the highest and 9 the lowest, for a task priority.
+func TaskPriorityICal(priority uint) int {
+	switch TaskPriorityName(priority) {
+	case TaskPriorityHighest:
+		return 1
+	case TaskPriorityHigh:
+		return 3
+	case TaskPriorityLow:
+		return 7
+	case TaskPriorityLowest:
+		return 9
+
+	default:
+		return 5
+	}
+}
+
+// ParseTaskPriorityICal maps an iCalendar PRIORITY value to a task priority.  0, meaning undefined, is neutral.
+func ParseTaskPriorityICal(priority int) uint {
+	switch {
+	case priority <= 0 || priority == 5:
+		return TaskPriorityNumber(TaskPriorityNeutral)
+	case priority <= 2:
+		return TaskPriorityNumber(TaskPriorityHighest)
+	case priority <= 4:
+		return TaskPriorityNumber(TaskPriorityHigh)
+	case priority <= 7:
+		return TaskPriorityNumber(TaskPriorityLow)
+
+	default:
+		return TaskPriorityNumber(TaskPriorityLowest)
+	}
+}
+
+// icalWriter writes CRLF terminated, folded iCalendar content lines.
+type icalWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (iw *icalWriter) line(name, value string) {
+	if iw.err != nil {
+		return
+	}
+	line := name + ":" + value
+	for len(line) > icalMaxLineOctets {
+		// never split a multibyte character across lines.
+		cut := icalMaxLineOctets
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		if _, iw.err = iw.w.WriteString(line[:cut] + "\r\n"); iw.err != nil {
+			return
+		}
+		line = " " + line[cut:]
+	}
+	_, iw.err = iw.w.WriteString(line + "\r\n")
+}
+
+func (iw *icalWriter) text(name, value string) {
+	iw.line(name, icalEscapeText(value))
+}
+
+func (iw *icalWriter) time(name string, tm time.Time) {
+	iw.line(name, tm.UTC().Format(icalTimeFormatUTC))
+}
+
+func icalEscapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+func icalUnescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// icalSplitList splits a comma separated property value, respecting escaped commas.
+func icalSplitList(s string) []string {
+	var (
+		out   []string
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			out = append(out, icalUnescapeText(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(out, icalUnescapeText(s[start:]))
+}
+
+// ExportICal writes the tasks selected by opts to w as an iCalendar file of VTODO items.  The task's list label and
+// tags are written as CATEGORIES.
+func ExportICal(ctx context.Context, db *gorm.DB, w io.Writer, opts ...ModelQueryOpt) error {
+	tasks, err := FindModel[Task](ctx, db, append(opts, WithPreload("TaskList"), WithPreload("Tags"))...)
+	if err != nil {
+		return fmt.Errorf("error reading tasks: %w", err)
+	}
+
+	now := time.Now()
+	iw := icalWriter{w: bufio.NewWriter(w)}
+
+	iw.line("BEGIN", "VCALENDAR")
+	iw.line("VERSION", "2.0")
+	iw.line("PRODID", icalProdID)
+	iw.line("CALSCALE", "GREGORIAN")
+
+	for _, task := range tasks {
+		iw.line("BEGIN", "VTODO")
+		iw.line("UID", fmt.Sprintf("task-%d-%d@it488", task.ID, task.CreatedAt.Unix()))
+		iw.time("DTSTAMP", now)
+		iw.time("CREATED", task.CreatedAt)
+		iw.time("LAST-MODIFIED", task.UpdatedAt)
+		iw.text("SUMMARY", task.Label)
+		if task.Description != "" {
+			iw.text("DESCRIPTION", task.Description)
+		}
+		if !task.DueDate.IsZero() {
+			iw.time("DUE", task.DueDate)
+		}
+		iw.line("STATUS", TaskStatusICal(task.Status))
+		iw.line("PRIORITY", strconv.Itoa(TaskPriorityICal(task.UserPriority)))
+
+		var categories []string
+		if task.TaskList != nil {
+			categories = append(categories, icalEscapeText(task.TaskList.Label))
+		}
+		for _, tag := range task.Tags {
+			categories = append(categories, icalEscapeText(tag.Label))
+		}
+		if len(categories) > 0 {
+			iw.line("CATEGORIES", strings.Join(categories, ","))
+		}
+
+		if task.Recurrence != "" {
+			iw.line("RRULE", task.Recurrence)
+		}
+		iw.line("END", "VTODO")
+	}
+
+	iw.line("END", "VCALENDAR")
+
+	if iw.err != nil {
+		return iw.err
+	}
+	return iw.w.Flush()
+}
+
+// icalProperty is a single unfolded content line.
+type icalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// icalComponent holds the properties of a VTODO or VEVENT, keyed by upper-cased property name.
+type icalComponent struct {
+	Kind  string
+	Props map[string][]icalProperty
+}
+
+func (c icalComponent) first(name string) (icalProperty, bool) {
+	props := c.Props[name]
+	if len(props) == 0 {
+		return icalProperty{}, false
+	}
+	return props[0], true
+}
+
+func parseICalProperty(line string) (icalProperty, error) {
+	var (
+		prop    icalProperty
+		inQuote bool
+		colon   = -1
+	)
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			inQuote = !inQuote
+		case ':':
+			if !inQuote {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return prop, fmt.Errorf("invalid content line %q", line)
+	}
+
+	prop.Value = line[colon+1:]
+	parts := strings.Split(line[:colon], ";")
+	prop.Name = strings.ToUpper(parts[0])
+	prop.Params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		prop.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return prop, nil
+}
+
+// parseICalComponents reads every VTODO and VEVENT out of an iCalendar stream.
+func parseICalComponents(r io.Reader) ([]icalComponent, error) {
+	var (
+		lines []string
+		sc    = bufio.NewScanner(r)
+	)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("error reading calendar: %w", err)
+	}
+
+	var (
+		out     []icalComponent
+		current *icalComponent
+		depth   int
+		sawCal  bool
+	)
+	for _, line := range lines {
+		prop, err := parseICalProperty(line)
+		if err != nil {
+			return nil, err
+		}
+		switch prop.Name {
+		case "BEGIN":
+			kind := strings.ToUpper(prop.Value)
+			if kind == "VCALENDAR" {
+				sawCal = true
+			}
+			if current != nil {
+				// nested components such as VALARM are skipped.
+				depth++
+				continue
+			}
+			if kind == "VTODO" || kind == "VEVENT" {
+				current = &icalComponent{Kind: kind, Props: make(map[string][]icalProperty)}
+			}
+		case "END":
+			if current == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			out = append(out, *current)
+			current = nil
+		default:
+			if current != nil && depth == 0 {
+				current.Props[prop.Name] = append(current.Props[prop.Name], prop)
+			}
+		}
+	}
+
+	if !sawCal {
+		return nil, errors.New("not an iCalendar file")
+	}
+	return out, nil
+}
+
+func parseICalTime(prop icalProperty) (time.Time, error) {
+	value := strings.TrimSpace(prop.Value)
+	if strings.EqualFold(prop.Params["VALUE"], "DATE") || len(value) == len(icalDateFormat) {
+		return time.ParseInLocation(icalDateFormat, value, time.Local)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(icalTimeFormatUTC, value)
+	}
+	loc := time.Local
+	if tzid := prop.Params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc = l
+		} else {
+			log.Warn("Unknown iCalendar time zone, using local time", "tzid", tzid)
+		}
+	}
+	return time.ParseInLocation(icalTimeFormat, value, loc)
+}
+
+// ImportICal adds every VTODO and VEVENT found in r to taskList, returning the number of tasks created.  CATEGORIES
+// become tags, except those naming an existing task list.
+func ImportICal(ctx context.Context, db *gorm.DB, r io.Reader, taskList *TaskList) (int, error) {
+	components, err := parseICalComponents(r)
+	if err != nil {
+		return 0, err
+	}
+
+	taskLists, err := FindModel[TaskList](ctx, db)
+	if err != nil {
+		return 0, fmt.Errorf("error reading task lists: %w", err)
+	}
+	isListLabel := func(label string) bool {
+		for _, tl := range taskLists {
+			if strings.EqualFold(tl.Label, label) {
+				return true
+			}
+		}
+		return false
+	}
+
+	created := 0
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, c := range components {
+			task := Task{
+				Label:        icalUntitledTask,
+				Status:       TaskStatusTodo,
+				SortOrder:    GetNextTaskOrderNum(),
+				UserPriority: TaskPriorityNumber(TaskPriorityNeutral),
+				TaskListID:   sql.Null[int]{V: int(taskList.ID), Valid: true},
+			}
+
+			if p, ok := c.first("SUMMARY"); ok && strings.TrimSpace(p.Value) != "" {
+				task.Label = strings.TrimSpace(icalUnescapeText(p.Value))
+			}
+			if p, ok := c.first("DESCRIPTION"); ok {
+				task.Description = icalUnescapeText(p.Value)
+			}
+			if p, ok := c.first("STATUS"); ok {
+				task.Status = ParseTaskStatusICal(p.Value)
+			}
+			if p, ok := c.first("PRIORITY"); ok {
+				if n, err := strconv.Atoi(strings.TrimSpace(p.Value)); err == nil {
+					task.UserPriority = ParseTaskPriorityICal(n)
+				}
+			}
+
+			due, ok := c.first("DUE")
+			if !ok || c.Kind == "VEVENT" {
+				due, ok = c.first("DTSTART")
+			}
+			if ok {
+				tm, err := parseICalTime(due)
+				if err != nil {
+					return fmt.Errorf("error importing %q: invalid %s %q", task.Label, due.Name, due.Value)
+				}
+				task.DueDate = tm
+			}
+
+			if p, ok := c.first("RRULE"); ok {
+				if rule, err := ParseTaskRecurrence(p.Value); err != nil {
+					log.Warn("Ignoring unsupported recurrence rule", "task", task.Label, "rrule", p.Value, "err", err)
+				} else {
+					task.Recurrence = rule.String()
+				}
+			}
+
+			if err := CreateTask(tx, &task); err != nil {
+				return fmt.Errorf("error importing %q: %w", task.Label, err)
+			}
+
+			var tags []string
+			for _, p := range c.Props["CATEGORIES"] {
+				for _, category := range icalSplitList(p.Value) {
+					if category = strings.TrimSpace(category); category != "" && !isListLabel(category) {
+						tags = append(tags, category)
+					}
+				}
+			}
+			if len(tags) > 0 {
+				if err := SetTaskTags(tx, task.ID, ParseTagLabels(strings.Join(tags, ","))); err != nil {
+					return err
+				}
+			}
+
+			created++
+		}
+		return nil
+	})
+
+	return created, err
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// ExportVersion is the version of ExportDocument written by ExportJSON.  Bump it whenever the document changes
+	// in a way older versions can't read.
+	ExportVersion = 1
+
+	ImportModeMerge   = "merge"
+	ImportModeReplace = "replace"
+)
+
+var (
+	ImportModes = []string{
+		ImportModeMerge,
+		ImportModeReplace,
+	}
+)
+
+// ExportDocument is the versioned JSON representation of the whole database.  IDs are only used to link tasks to
+// lists within the document and are reassigned on import.
+type ExportDocument struct {
+	Version    int              `json:"version"`
+	ExportedAt time.Time        `json:"exported_at"`
+	TaskLists  []ExportTaskList `json:"task_lists"`
+	Tasks      []ExportTask     `json:"tasks"`
+}
+
+type ExportTaskList struct {
+	ID          uint       `json:"id"`
+	Label       string     `json:"label"`
+	Description string     `json:"description"`
+	Color       string     `json:"color,omitempty"`
+	Date        time.Time  `json:"date"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+type ExportSubtask struct {
+	Label string `json:"label"`
+	Done  bool   `json:"done"`
+}
+
+type ExportTask struct {
+	ID          uint            `json:"id"`
+	ListID      *uint           `json:"list_id"`
+	Label       string          `json:"label"`
+	Description string          `json:"description"`
+	Status      string          `json:"status"`
+	Priority    string          `json:"priority"`
+	Order       uint            `json:"order"`
+	DueDate     time.Time       `json:"due_date"`
+	Recurrence  string          `json:"recurrence,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Subtasks    []ExportSubtask `json:"subtasks,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+}
+
+type ImportResult struct {
+	TaskLists int
+	Tasks     int
+}
+
+func exportDeletedAt(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	tm := deletedAt.Time
+	return &tm
+}
+
+func importDeletedAt(deletedAt *time.Time) gorm.DeletedAt {
+	if deletedAt == nil {
+		return gorm.DeletedAt{}
+	}
+	return gorm.DeletedAt{Time: *deletedAt, Valid: true}
+}
+
+// BuildExportDocument reads every task list and task into an ExportDocument, optionally including soft-deleted rows.
+func BuildExportDocument(ctx context.Context, db *gorm.DB, includeDeleted bool) (*ExportDocument, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		if includeDeleted {
+			return db.Unscoped()
+		}
+		return db
+	}
+
+	taskLists, err := FindModel[TaskList](ctx, db, scope, WithSort("id asc"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading task lists: %w", err)
+	}
+
+	tasks, err := FindModel[Task](ctx, db, scope, WithSort("id asc"), WithPreload("Tags"), WithPreload("Subtasks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("error reading tasks: %w", err)
+	}
+
+	doc := ExportDocument{
+		Version:    ExportVersion,
+		ExportedAt: time.Now(),
+		TaskLists:  make([]ExportTaskList, len(taskLists)),
+		Tasks:      make([]ExportTask, len(tasks)),
+	}
+
+	for i, tl := range taskLists {
+		doc.TaskLists[i] = ExportTaskList{
+			ID:          tl.ID,
+			Label:       tl.Label,
+			Description: tl.Description,
+			Color:       tl.Color,
+			Date:        tl.Date,
+			CreatedAt:   tl.CreatedAt,
+			UpdatedAt:   tl.UpdatedAt,
+			DeletedAt:   exportDeletedAt(tl.DeletedAt),
+		}
+	}
+
+	for i, t := range tasks {
+		et := ExportTask{
+			ID:          t.ID,
+			Label:       t.Label,
+			Description: t.Description,
+			Status:      TaskStatusTitle(t.Status),
+			Priority:    TaskPriorityName(t.UserPriority),
+			Order:       t.SortOrder,
+			DueDate:     t.DueDate,
+			Recurrence:  t.Recurrence,
+			CreatedAt:   t.CreatedAt,
+			UpdatedAt:   t.UpdatedAt,
+			DeletedAt:   exportDeletedAt(t.DeletedAt),
+		}
+		if t.TaskListID.Valid {
+			listID := uint(t.TaskListID.V)
+			et.ListID = &listID
+		}
+		for _, tag := range t.Tags {
+			et.Tags = append(et.Tags, tag.Label)
+		}
+		for _, st := range t.Subtasks {
+			et.Subtasks = append(et.Subtasks, ExportSubtask{Label: st.Label, Done: st.Done})
+		}
+		doc.Tasks[i] = et
+	}
+
+	return &doc, nil
+}
+
+// ExportJSON writes the database to w as an ExportDocument.
+func ExportJSON(ctx context.Context, db *gorm.DB, w io.Writer, includeDeleted bool) error {
+	doc, err := BuildExportDocument(ctx, db, includeDeleted)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// ImportJSON reads an ExportDocument from r and imports it.  In ImportModeReplace all existing data is removed first,
+// in ImportModeMerge the document's rows are added alongside it.
+func ImportJSON(ctx context.Context, db *gorm.DB, r io.Reader, mode string) (ImportResult, error) {
+	var doc ExportDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return ImportResult{}, fmt.Errorf("error decoding export document: %w", err)
+	}
+	return ImportDocument(ctx, db, &doc, mode)
+}
+
+func ImportDocument(ctx context.Context, db *gorm.DB, doc *ExportDocument, mode string) (ImportResult, error) {
+	var result ImportResult
+
+	switch {
+	case doc.Version == 0:
+		return result, errors.New("export document has no version")
+	case doc.Version > ExportVersion:
+		return result, fmt.Errorf("export document version %d is newer than the supported version %d", doc.Version, ExportVersion)
+	}
+	if !slices.Contains(ImportModes, mode) {
+		return result, fmt.Errorf("unknown import mode %q", mode)
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if mode == ImportModeReplace {
+			if err := clearAllData(tx); err != nil {
+				return err
+			}
+		}
+
+		listIDs := make(map[uint]uint, len(doc.TaskLists))
+		for _, etl := range doc.TaskLists {
+			tl := TaskList{
+				Label:       etl.Label,
+				Description: etl.Description,
+				Color:       etl.Color,
+				Date:        etl.Date,
+			}
+			tl.CreatedAt = etl.CreatedAt
+			tl.UpdatedAt = etl.UpdatedAt
+			tl.DeletedAt = importDeletedAt(etl.DeletedAt)
+			if err := tx.Create(&tl).Error; err != nil {
+				return fmt.Errorf("error importing task list %q: %w", etl.Label, err)
+			}
+			listIDs[etl.ID] = tl.ID
+			result.TaskLists++
+		}
+
+		// keep the relative ordering of tasks while giving them fresh, unique order numbers.
+		tasks := slices.Clone(doc.Tasks)
+		slices.SortStableFunc(tasks, func(a, b ExportTask) int {
+			return cmp.Compare(a.Order, b.Order)
+		})
+
+		for _, et := range tasks {
+			status, err := ParseTaskStatus(et.Status)
+			if err != nil {
+				return fmt.Errorf("error importing task %q: %w", et.Label, err)
+			}
+			priority, err := ParseTaskPriority(et.Priority)
+			if err != nil {
+				return fmt.Errorf("error importing task %q: %w", et.Label, err)
+			}
+			t := Task{
+				Label:        et.Label,
+				Description:  et.Description,
+				Status:       status,
+				SortOrder:    GetNextTaskOrderNum(),
+				UserPriority: priority,
+				DueDate:      et.DueDate,
+				Recurrence:   et.Recurrence,
+			}
+			t.CreatedAt = et.CreatedAt
+			t.UpdatedAt = et.UpdatedAt
+			t.DeletedAt = importDeletedAt(et.DeletedAt)
+			if et.ListID != nil {
+				if listID, ok := listIDs[*et.ListID]; ok {
+					t.TaskListID = sql.Null[int]{V: int(listID), Valid: true}
+				}
+			}
+			if err = CreateTask(tx, &t); err != nil {
+				return fmt.Errorf("error importing task %q: %w", et.Label, err)
+			}
+
+			subtasks := make([]Subtask, len(et.Subtasks))
+			for i, est := range et.Subtasks {
+				subtasks[i] = Subtask{Label: est.Label, Done: est.Done}
+			}
+			if err = SaveSubtasks(tx, t.ID, subtasks); err != nil {
+				return err
+			}
+			if err = SetTaskTags(tx, t.ID, ParseTagLabels(strings.Join(et.Tags, ","))); err != nil {
+				return err
+			}
+			result.Tasks++
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// clearAllData permanently removes every row of user data, soft-deleted or not.
+func clearAllData(tx *gorm.DB) error {
+	for _, table := range []string{"task_tags", "subtasks", "tags", "tasks", "task_lists"} {
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM `%s`", table)).Error; err != nil {
+			return fmt.Errorf("error clearing %s: %w", table, err)
+		}
+	}
+	return nil
+}
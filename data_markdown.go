@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	markdownReportDateFormat = "Mon Jan 2 2006"
+)
+
+var (
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		"`", "\\`",
+		"*", `\*`,
+		"_", `\_`,
+		"[", `\[`,
+		"]", `\]`,
+		"<", `\<`,
+	)
+)
+
+// MarkdownReport is a Markdown document of tasks grouped by status, for pasting into status reports.
+type MarkdownReport struct {
+	Title       string
+	Subtitle    string
+	Description string
+	Tasks       []Task
+
+	// ShowList adds each task's list label, for reports spanning several lists.
+	ShowList bool
+}
+
+// NewTaskListMarkdownReport builds a report of every task in a task list.
+func NewTaskListMarkdownReport(ctx context.Context, db *gorm.DB, taskList TaskList) (*MarkdownReport, error) {
+	tasks, err := findMarkdownReportTasks(ctx, db, taskListTasksModelQueryOpt(taskList.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &MarkdownReport{
+		Title:       taskList.Label,
+		Subtitle:    taskList.Date.Format(markdownReportDateFormat),
+		Description: taskList.Description,
+		Tasks:       tasks,
+	}, nil
+}
+
+// NewDueDateMarkdownReport builds a report of every task due on the days from through to, inclusive.
+func NewDueDateMarkdownReport(ctx context.Context, db *gorm.DB, from, to time.Time) (*MarkdownReport, error) {
+	from = StartOfDay(from)
+	to = StartOfDay(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("report end %s is before its start %s", to.Format(time.DateOnly), from.Format(time.DateOnly))
+	}
+
+	tasks, err := findMarkdownReportTasks(ctx, db, dueBetweenModelQueryOpt(from, to.AddDate(0, 0, 1)))
+	if err != nil {
+		return nil, err
+	}
+
+	title := "Tasks due " + from.Format(markdownReportDateFormat)
+	if !to.Equal(from) {
+		title += " to " + to.Format(markdownReportDateFormat)
+	}
+	return &MarkdownReport{
+		Title:    title,
+		Tasks:    tasks,
+		ShowList: true,
+	}, nil
+}
+
+func findMarkdownReportTasks(ctx context.Context, db *gorm.DB, opts ...ModelQueryOpt) ([]Task, error) {
+	tasks, err := FindModel[Task](ctx, db, append(
+		opts,
+		WithPreload("TaskList"),
+		WithPreload("Tags"),
+		WithPreload("Subtasks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc")
+		}),
+	)...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// WriteTo writes the report as Markdown.
+func (r MarkdownReport) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+
+	b.WriteString("# " + markdownEscaper.Replace(r.Title) + "\n\n")
+	if r.Subtitle != "" {
+		b.WriteString("_" + markdownEscaper.Replace(r.Subtitle) + "_\n\n")
+	}
+	if desc := strings.TrimSpace(r.Description); desc != "" {
+		b.WriteString(desc + "\n\n")
+	}
+	if len(r.Tasks) == 0 {
+		b.WriteString("No tasks.\n")
+	}
+
+	for _, status := range TaskStatusTitles {
+		var tasks []Task
+		for _, task := range r.Tasks {
+			if TaskStatusTitle(task.Status) == status {
+				tasks = append(tasks, task)
+			}
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+
+		b.WriteString(fmt.Sprintf("## %s (%d)\n\n", status, len(tasks)))
+		for _, task := range tasks {
+			r.writeTask(&b, task)
+		}
+		b.WriteString("\n")
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (r MarkdownReport) writeTask(b *strings.Builder, task Task) {
+	label := markdownEscaper.Replace(task.Label)
+	switch task.Status {
+	case TaskStatusDone:
+		b.WriteString("- [x] " + label)
+	case TaskStatusSkip:
+		b.WriteString("- [ ] ~~" + label + "~~")
+	default:
+		b.WriteString("- [ ] **" + label + "**")
+	}
+
+	details := []string{"priority: " + strings.ToLower(TaskPriorityName(task.UserPriority))}
+	if !task.DueDate.IsZero() {
+		details = append(details, "due: "+FormatDateTime(task.DueDate))
+	}
+	if r.ShowList && task.TaskList != nil {
+		details = append(details, "list: "+markdownEscaper.Replace(task.TaskList.Label))
+	}
+	if rec, err := ParseTaskRecurrence(task.Recurrence); err == nil {
+		details = append(details, "repeats: "+rec.Describe())
+	}
+	for _, tag := range task.Tags {
+		details = append(details, "#"+markdownEscaper.Replace(tag.Label))
+	}
+	b.WriteString(" — " + strings.Join(details, " · ") + "\n")
+
+	for _, st := range task.Subtasks {
+		box := "[ ]"
+		if st.Done {
+			box = "[x]"
+		}
+		b.WriteString("  - " + box + " " + markdownEscaper.Replace(st.Label) + "\n")
+	}
+
+	if desc := strings.TrimSpace(task.Description); desc != "" {
+		// indent the description so it renders as part of the list item.
+		b.WriteString("\n")
+		for _, line := range strings.Split(desc, "\n") {
+			if line == "" {
+				b.WriteString("\n")
+				continue
+			}
+			b.WriteString("  " + line + "\n")
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	todoTxtDateFormat = "2006-01-02"
+	todoTxtTimeFormat = "15:04"
+
+	// todo.txt has no standard way to express these, so they use the key:value extension syntax most tools preserve.
+	todoTxtKeyDue      = "due"
+	todoTxtKeyAt       = "at"
+	todoTxtKeyPriority = "pri"
+	todoTxtKeyStatus   = "status"
+	todoTxtKeyRepeat   = "rrule"
+
+	// todoTxtEscape starts a word of a label that would otherwise be read as a +project, @context or key:value.
+	todoTxtEscape = `\`
+)
+
+// TaskPriorityTodoTxt returns the todo.txt priority letter, A being the highest, for a task priority.
+func TaskPriorityTodoTxt(priority uint) string {
+	switch TaskPriorityName(priority) {
+	case TaskPriorityHighest:
+		return "A"
+	case TaskPriorityHigh:
+		return "B"
+	case TaskPriorityLow:
+		return "D"
+	case TaskPriorityLowest:
+		return "E"
+
+	default:
+		return "C"
+	}
+}
+
+// ParseTaskPriorityTodoTxt maps a todo.txt priority letter to a task priority.  Letters after E are lowest.
+func ParseTaskPriorityTodoTxt(letter string) (uint, error) {
+	if len(letter) != 1 || letter[0] < 'A' || letter[0] > 'Z' {
+		return 0, fmt.Errorf("invalid todo.txt priority %q", letter)
+	}
+	switch letter {
+	case "A":
+		return TaskPriorityNumber(TaskPriorityHighest), nil
+	case "B":
+		return TaskPriorityNumber(TaskPriorityHigh), nil
+	case "C":
+		return TaskPriorityNumber(TaskPriorityNeutral), nil
+	case "D":
+		return TaskPriorityNumber(TaskPriorityLow), nil
+
+	default:
+		return TaskPriorityNumber(TaskPriorityLowest), nil
+	}
+}
+
+// todoTxtWord replaces whitespace so a list or tag label can be written as a single +project or @context.
+func todoTxtWord(label string) string {
+	return strings.Join(strings.Fields(label), "_")
+}
+
+// todoTxtMetadata splits a key:value word.  Keys are letters only, so times like 10:30 stay in the label, as do URLs.
+func todoTxtMetadata(word string) (key, value string, ok bool) {
+	key, value, ok = strings.Cut(word, ":")
+	if !ok || key == "" || value == "" || strings.HasPrefix(value, "//") {
+		return "", "", false
+	}
+	for _, r := range key {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return "", "", false
+		}
+	}
+	return key, value, true
+}
+
+// todoTxtLabelWord escapes a word of a task label that ParseTodoTxt would read as something other than the label.
+func todoTxtLabelWord(word string) string {
+	special := strings.HasPrefix(word, todoTxtEscape) ||
+		(len(word) > 1 && (word[0] == '+' || word[0] == '@'))
+	if _, _, ok := todoTxtMetadata(word); ok || special {
+		return todoTxtEscape + word
+	}
+	return word
+}
+
+// FormatTodoTxt renders a task as a single todo.txt line.  The task's TaskList and Tags are written as +project and
+// @contexts when loaded.  The description has no place in todo.txt and is not written.
+func FormatTodoTxt(task Task) string {
+	var parts []string
+
+	closed := TaskStatusIsClosed(task.Status)
+	if closed {
+		parts = append(parts, "x", task.UpdatedAt.Format(todoTxtDateFormat))
+	} else {
+		parts = append(parts, "("+TaskPriorityTodoTxt(task.UserPriority)+")")
+	}
+	if !task.CreatedAt.IsZero() {
+		parts = append(parts, task.CreatedAt.Format(todoTxtDateFormat))
+	}
+
+	for _, word := range strings.Fields(task.Label) {
+		parts = append(parts, todoTxtLabelWord(word))
+	}
+
+	if task.TaskList != nil {
+		parts = append(parts, "+"+todoTxtWord(task.TaskList.Label))
+	}
+	for _, tag := range task.Tags {
+		parts = append(parts, "@"+todoTxtWord(tag.Label))
+	}
+
+	if !task.DueDate.IsZero() {
+		due := task.DueDate.In(time.Local)
+		parts = append(parts, todoTxtKeyDue+":"+due.Format(todoTxtDateFormat))
+		if due.Hour() != 0 || due.Minute() != 0 {
+			parts = append(parts, todoTxtKeyAt+":"+due.Format(todoTxtTimeFormat))
+		}
+	}
+	if closed {
+		// completed todo.txt tasks conventionally drop their priority, keep it around so it survives a round trip.
+		parts = append(parts, todoTxtKeyPriority+":"+TaskPriorityTodoTxt(task.UserPriority))
+	}
+	switch task.Status {
+	case TaskStatusInProgress:
+		parts = append(parts, todoTxtKeyStatus+":in-progress")
+	case TaskStatusSkip:
+		parts = append(parts, todoTxtKeyStatus+":skip")
+	}
+	if task.Recurrence != "" {
+		parts = append(parts, todoTxtKeyRepeat+":"+task.Recurrence)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// TodoTxtTask is a task parsed from a todo.txt line along with the parts that need resolving against the database.
+type TodoTxtTask struct {
+	Task Task
+
+	// Project is the first +project, naming the task list.  Any further projects are kept in the description.
+	Project string
+
+	// Contexts are the @contexts, imported as tags.
+	Contexts []string
+}
+
+func isTodoTxtDate(s string) bool {
+	_, err := time.ParseInLocation(todoTxtDateFormat, s, time.Local)
+	return err == nil
+}
+
+// ParseTodoTxt parses a single todo.txt line.  Unrecognised key:value pairs and extra projects are kept as
+// "key: value" lines in the task description.  A word starting with a backslash is taken as part of the label, as
+// written by FormatTodoTxt.
+func ParseTodoTxt(line string) (*TodoTxtTask, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty todo.txt line")
+	}
+
+	out := TodoTxtTask{
+		Task: Task{
+			Status:       TaskStatusTodo,
+			UserPriority: TaskPriorityNumber(TaskPriorityNeutral),
+		},
+	}
+	task := &out.Task
+
+	if fields[0] == "x" {
+		task.Status = TaskStatusDone
+		fields = fields[1:]
+		if len(fields) > 0 && isTodoTxtDate(fields[0]) {
+			task.UpdatedAt, _ = time.ParseInLocation(todoTxtDateFormat, fields[0], time.Local)
+			fields = fields[1:]
+		}
+	} else if f := fields[0]; len(f) == 3 && f[0] == '(' && f[2] == ')' {
+		priority, err := ParseTaskPriorityTodoTxt(f[1:2])
+		if err != nil {
+			return nil, err
+		}
+		task.UserPriority = priority
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && isTodoTxtDate(fields[0]) {
+		task.CreatedAt, _ = time.ParseInLocation(todoTxtDateFormat, fields[0], time.Local)
+		fields = fields[1:]
+	}
+
+	var (
+		words    []string
+		metadata []string
+		dueTime  string
+	)
+	for _, f := range fields {
+		switch {
+		case strings.HasPrefix(f, todoTxtEscape):
+			words = append(words, f[len(todoTxtEscape):])
+			continue
+		case len(f) > 1 && f[0] == '+':
+			if out.Project == "" {
+				out.Project = strings.ReplaceAll(f[1:], "_", " ")
+			} else {
+				metadata = append(metadata, "project: "+f[1:])
+			}
+			continue
+		case len(f) > 1 && f[0] == '@':
+			out.Contexts = append(out.Contexts, strings.ReplaceAll(f[1:], "_", " "))
+			continue
+		}
+
+		key, value, ok := todoTxtMetadata(f)
+		if !ok {
+			words = append(words, f)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case todoTxtKeyDue:
+			due, err := time.ParseInLocation(todoTxtDateFormat, value, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("invalid due date %q", value)
+			}
+			task.DueDate = due
+		case todoTxtKeyAt:
+			dueTime = value
+		case todoTxtKeyPriority:
+			priority, err := ParseTaskPriorityTodoTxt(strings.ToUpper(value))
+			if err != nil {
+				return nil, err
+			}
+			task.UserPriority = priority
+		case todoTxtKeyStatus:
+			switch strings.ToLower(value) {
+			case "in-progress", "in_progress", "inprogress":
+				if task.Status == TaskStatusTodo {
+					task.Status = TaskStatusInProgress
+				}
+			case "skip", "skipped":
+				task.Status = TaskStatusSkip
+			default:
+				metadata = append(metadata, key+": "+value)
+			}
+		case todoTxtKeyRepeat:
+			rule, err := ParseTaskRecurrence(value)
+			if err != nil {
+				return nil, err
+			}
+			task.Recurrence = rule.String()
+
+		default:
+			metadata = append(metadata, key+": "+value)
+		}
+	}
+
+	if dueTime != "" && !task.DueDate.IsZero() {
+		tm, err := time.Parse(todoTxtTimeFormat, dueTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due time %q", dueTime)
+		}
+		task.DueDate = task.DueDate.Add(time.Duration(tm.Hour())*time.Hour + time.Duration(tm.Minute())*time.Minute)
+	}
+
+	task.Label = strings.Join(words, " ")
+	if task.Label == "" {
+		return nil, fmt.Errorf("todo.txt line has no description")
+	}
+	task.Description = strings.Join(metadata, "\n")
+
+	return &out, nil
+}
+
+// ExportTodoTxt writes the tasks selected by opts to w, one todo.txt line per task.
+func ExportTodoTxt(ctx context.Context, db *gorm.DB, w io.Writer, opts ...ModelQueryOpt) error {
+	tasks, err := FindModel[Task](ctx, db, append(opts, WithPreload("TaskList"), WithPreload("Tags"))...)
+	if err != nil {
+		return fmt.Errorf("error reading tasks: %w", err)
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, task := range tasks {
+		if _, err = bw.WriteString(FormatTodoTxt(task) + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ImportTodoTxt adds every task in the todo.txt stream r, returning the number of tasks created.  Tasks go to the list
+// named by their +project, which is created if it doesn't exist yet, or to defaultList when they have none.
+func ImportTodoTxt(ctx context.Context, db *gorm.DB, r io.Reader, defaultList *TaskList) (int, error) {
+	var parsed []*TodoTxtTask
+
+	sc := bufio.NewScanner(r)
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		tt, err := ParseTodoTxt(line)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		if tt.Project == "" && defaultList == nil {
+			return 0, fmt.Errorf("line %d: task has no +project and no task list was chosen", lineNum)
+		}
+		parsed = append(parsed, tt)
+	}
+	if err := sc.Err(); err != nil {
+		return 0, fmt.Errorf("error reading todo.txt: %w", err)
+	}
+
+	created := 0
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		projects := make(map[string]*TaskList)
+		for _, tt := range parsed {
+			taskList := defaultList
+			if tt.Project != "" {
+				var err error
+				if taskList, err = todoTxtProjectList(ctx, tx, projects, tt.Project); err != nil {
+					return err
+				}
+			}
+
+			task := tt.Task
+			task.SortOrder = GetNextTaskOrderNum()
+			task.TaskListID = sql.Null[int]{V: int(taskList.ID), Valid: true}
+			if err := CreateTask(tx, &task); err != nil {
+				return fmt.Errorf("error importing %q: %w", task.Label, err)
+			}
+			if len(tt.Contexts) > 0 {
+				if err := SetTaskTags(tx, task.ID, ParseTagLabels(strings.Join(tt.Contexts, ","))); err != nil {
+					return err
+				}
+			}
+			created++
+		}
+		return nil
+	})
+
+	return created, err
+}
+
+// todoTxtProjectList finds the task list for a +project, matching labels with whitespace written as underscores, and
+// creates it when there is none.
+func todoTxtProjectList(ctx context.Context, tx *gorm.DB, cache map[string]*TaskList, project string) (*TaskList, error) {
+	key := strings.ToLower(todoTxtWord(project))
+	if taskList, ok := cache[key]; ok {
+		return taskList, nil
+	}
+
+	taskLists, err := FindModel[TaskList](ctx, tx, WithSort("id asc"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading task lists: %w", err)
+	}
+	for i := range taskLists {
+		if strings.ToLower(todoTxtWord(taskLists[i].Label)) == key {
+			cache[key] = &taskLists[i]
+			return cache[key], nil
+		}
+	}
+
+	taskList := TaskList{Label: project, Date: time.Now()}
+	if err = tx.Create(&taskList).Error; err != nil {
+		return nil, fmt.Errorf("error creating task list %q: %w", project, err)
+	}
+	cache[key] = &taskList
+	return &taskList, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestParseTodoTxtLabel(t *testing.T) {
+	tests := []struct {
+		line  string
+		label string
+		desc  string
+	}{
+		{line: "(C) Call at 10:30", label: "Call at 10:30"},
+		{line: "(C) Read https://example.com today", label: "Read https://example.com today"},
+		{line: "(C) Pay rent ref:42", label: "Pay rent", desc: "ref: 42"},
+		{line: `(C) Write \note:this down`, label: "Write note:this down"},
+		{line: `(C) Email \+1 and \@bob`, label: "Email +1 and @bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := ParseTodoTxt(tt.line)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Task.Label != tt.label {
+				t.Errorf("label %q, want %q", got.Task.Label, tt.label)
+			}
+			if got.Task.Description != tt.desc {
+				t.Errorf("description %q, want %q", got.Task.Description, tt.desc)
+			}
+		})
+	}
+}
+
+func TestFormatTodoTxtLabelRoundTrip(t *testing.T) {
+	for _, label := range []string{
+		"Call at 10:30",
+		"Write note:this down",
+		"Email +1 and @bob",
+		`Escape \n and \\`,
+		"Read https://example.com",
+	} {
+		t.Run(label, func(t *testing.T) {
+			got, err := ParseTodoTxt(FormatTodoTxt(Task{Label: label, Status: TaskStatusTodo}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Task.Label != label {
+				t.Errorf("label %q after a round trip", got.Task.Label)
+			}
+			if got.Task.Description != "" {
+				t.Errorf("description %q after a round trip", got.Task.Description)
+			}
+		})
+	}
+}
@@ -1,13 +1,106 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
-const (
-	TimestampDisplayFormat = "Jan _2 3:04:05PM"
+var (
+	displayFormat atomic.Pointer[DisplayFormat]
+
+	// dateTimeInputFormats are the layouts accepted by ParseDateTime, tried in order.
+	dateTimeInputFormats = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
 )
 
+// DisplayFormat holds the layouts dates and times are displayed with.
+type DisplayFormat struct {
+	Date string
+	Time string
+}
+
+// SetDisplayFormat changes how FormatDateTime and FormatDate display times.
+func SetDisplayFormat(f DisplayFormat) {
+	displayFormat.Store(&f)
+}
+
+func currentDisplayFormat() DisplayFormat {
+	if f := displayFormat.Load(); f != nil {
+		return *f
+	}
+	return DisplayFormat{Date: DateFormatOptions[0], Time: TimeFormatOptions[0]}
+}
+
 func FormatDateTime(tm time.Time) string {
-	return tm.Format(TimestampDisplayFormat)
+	f := currentDisplayFormat()
+	return tm.Format(f.Date + " " + f.Time)
+}
+
+func FormatDate(tm time.Time) string {
+	return tm.Format(currentDisplayFormat().Date)
+}
+
+func FormatTime(tm time.Time) string {
+	return tm.Format(currentDisplayFormat().Time)
+}
+
+// FormatHour displays the hour tm is in, e.g. "3PM" or "15:00" depending on whether times are shown in 24-hour form.
+func FormatHour(tm time.Time) string {
+	if strings.HasPrefix(currentDisplayFormat().Time, "15") {
+		return tm.Format("15:00")
+	}
+	return tm.Format("3PM")
+}
+
+// StartOfDay returns midnight at the start of tm's day, in local time.
+func StartOfDay(tm time.Time) time.Time {
+	tm = tm.Local()
+	return time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// StartOfWeek returns midnight at the start of tm's week, weeks starting on first.
+func StartOfWeek(tm time.Time, first time.Weekday) time.Time {
+	day := StartOfDay(tm)
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(first) + 7) % 7))
+}
+
+// StartOfMonth returns midnight on the first of tm's month, in local time.
+func StartOfMonth(tm time.Time) time.Time {
+	tm = tm.Local()
+	return time.Date(tm.Year(), tm.Month(), 1, 0, 0, 0, 0, time.Local)
+}
+
+// OnDay returns tm moved to day, keeping its time of day.
+func OnDay(tm, day time.Time) time.Time {
+	tm, day = tm.Local(), day.Local()
+	return time.Date(day.Year(), day.Month(), day.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), time.Local)
+}
+
+// ParseDateTime parses user provided dates and times in local time.  Besides the layouts in dateTimeInputFormats,
+// "now", "today" and "tomorrow" are understood.
+func ParseDateTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	now := time.Now()
+	switch strings.ToLower(s) {
+	case "now":
+		return now, nil
+	case "today":
+		return StartOfDay(now), nil
+	case "tomorrow":
+		return StartOfDay(now).AddDate(0, 0, 1), nil
+	}
+	for _, layout := range dateTimeInputFormats {
+		if tm, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return tm, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected a format like %q", s, "2006-01-02 15:04")
 }
@@ -61,38 +61,79 @@ func (gl gormLogger) Trace(_ context.Context, begin time.Time, fc func() (sql st
 	log.Debug("Query trace", "begin", begin, "sql", sql, "rows_affected", af, "err", err)
 }
 
-func openDB(dbFile string, logDebug bool) (*gorm.DB, error) {
+// DBRef is a swappable reference to the open database, shared by everything that must follow it when a backup is
+// restored.
+type DBRef struct {
+	ptr atomic.Pointer[gorm.DB]
+}
+
+func NewDBRef(db *gorm.DB) *DBRef {
+	ref := DBRef{}
+	ref.ptr.Store(db)
+	return &ref
+}
+
+func (r *DBRef) Get() *gorm.DB {
+	return r.ptr.Load()
+}
+
+// Swap replaces the database, returning the previous one.
+func (r *DBRef) Swap(db *gorm.DB) *gorm.DB {
+	return r.ptr.Swap(db)
+}
+
+// connectDB opens the sqlite database without touching its schema.
+func connectDB(dbFile string, logDebug bool) (*gorm.DB, error) {
 	log.Debug("Opening sqlite db...", "db", dbFile)
 
 	conf := &gorm.Config{
 		Logger: newGormLogger(logDebug),
 	}
-	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("%s?_pragma=foreign_keys(1)", dbFile)), conf)
+	return gorm.Open(sqlite.Open(fmt.Sprintf("%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", dbFile)), conf)
+}
+
+func openDB(dbFile string, logDebug bool) (*gorm.DB, error) {
+	db, err := connectDB(dbFile, logDebug)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Debug("Applying migrations...")
 
-	if err = db.AutoMigrate(&TaskList{}, &Task{}); err != nil {
+	if err = migrateDB(db); err != nil {
 		defer tryCloseDB(db)
 		return nil, fmt.Errorf("error applying migrations: %w", err)
 	}
 
-	var highestPriority sql.Null[uint]
-	row := db.Table("Tasks").Select("max(priority)").Row()
-	if err = row.Scan(&highestPriority); err != nil {
-		log.Error("Error finding highest task priority", "err", err)
-		panic(fmt.Sprintf("Error finding highest task priority: %v", err))
+	var highestSortOrder sql.Null[uint]
+	row := db.Table("tasks").Select("max(sort_order)").Row()
+	if err = row.Scan(&highestSortOrder); err != nil {
+		defer tryCloseDB(db)
+		return nil, fmt.Errorf("error finding highest task sort order: %w", err)
 	}
 
-	taskOrderSrc.Store(uint64(highestPriority.V))
+	taskOrderSrc.Store(uint64(highestSortOrder.V))
 
-	log.Debug("Found highest task priority", "task_priority", highestPriority)
+	log.Debug("Found highest task sort order", "sort_order", highestSortOrder)
 
 	return db, nil
 }
 
+// CreateTask inserts a new task.  gorm leaves zero values of columns with a default out of the insert, which would turn
+// the lowest priority into the column default, so a zero UserPriority is written explicitly afterward.
+func CreateTask(db *gorm.DB, task *Task) error {
+	lowest := task.UserPriority == 0
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(task).Error; err != nil {
+			return err
+		}
+		if lowest {
+			return tx.Model(task).Update("UserPriority", 0).Error
+		}
+		return nil
+	})
+}
+
 func tryCloseDB(db *gorm.DB) {
 	if db == nil {
 		return
@@ -109,7 +150,9 @@ type TaskList struct {
 	Label       string `gorm:"not null"`
 	Date        time.Time
 	Description string
-	Tasks       []Task `gorm:"constraint:OnDelete:CASCADE"`
+	// Color is a ListColorNames entry or "#rrggbb" hex, empty for none.
+	Color string
+	Tasks []Task `gorm:"constraint:OnDelete:CASCADE"`
 }
 
 type Task struct {
@@ -118,16 +161,22 @@ type Task struct {
 	Description string
 	Status      uint `gorm:"default:0;not null"`
 
-	// Priority is actually used as a priority _within tasks_, not user-defined priority.
-	// Its a poorly named column.
-	Priority uint `gorm:"unique;not null"`
+	// SortOrder orders tasks relative to each other, see GetNextTaskOrderNum.  UserPriority is the priority the user
+	// chose.
+	SortOrder uint `gorm:"unique;not null"`
 
 	UserPriority uint `gorm:"default:20;not null"`
 
 	DueDate time.Time
 
+	// Recurrence is an RRULE-style rule, see ParseTaskRecurrence.  Empty means the task does not repeat.
+	Recurrence string
+
 	TaskListID sql.Null[int]
 	TaskList   *TaskList
+
+	Subtasks []Subtask `gorm:"constraint:OnDelete:CASCADE"`
+	Tags     []Tag     `gorm:"many2many:task_tags;constraint:OnDelete:CASCADE"`
 }
 
 func (t Task) PriorityIcon() *canvas.Image {
@@ -139,3 +188,28 @@ func (t Task) PriorityIcon() *canvas.Image {
 		),
 	)
 }
+
+// SubtaskProgress returns the number of done and total checklist items.  Subtasks must have been loaded.
+func (t Task) SubtaskProgress() (done int, total int) {
+	for _, st := range t.Subtasks {
+		if st.Done {
+			done++
+		}
+	}
+	return done, len(t.Subtasks)
+}
+
+type Subtask struct {
+	gorm.Model
+	Label    string `gorm:"not null"`
+	Done     bool   `gorm:"default:false;not null"`
+	Position uint   `gorm:"default:0;not null"`
+
+	TaskID uint `gorm:"not null"`
+}
+
+type Tag struct {
+	gorm.Model
+	Label string `gorm:"unique;not null"`
+	Tasks []Task `gorm:"many2many:task_tags;constraint:OnDelete:CASCADE"`
+}
@@ -92,23 +92,36 @@ func FindAssociation[T, E any](ctx context.Context, db *gorm.DB, base T, column
 
 func todaysTasksModelQueryOpt() ModelQueryOpt {
 	return func(db *gorm.DB) *gorm.DB {
-		return WithSort("due_date asc")(WithSort("id asc")(WithPreload("TaskList")(db))).
+		return WithSort("id asc")(WithSort("due_date asc")(WithPreload("TaskList")(db))).
 			Where("date(`tasks`.`due_date`, 'localtime') = date('now', 'localtime')")
 	}
 }
 
-func GetListForTask(ctx context.Context, db *gorm.DB, task Task) *TaskList {
+func statusTasksModelQueryOpt(statuses ...uint) ModelQueryOpt {
+	return func(db *gorm.DB) *gorm.DB {
+		return WithSort("id asc")(WithSort("due_date asc")(WithPreload("TaskList")(db))).
+			Where("status IN ?", statuses)
+	}
+}
+
+func taskListTasksModelQueryOpt(taskListID uint) ModelQueryOpt {
+	return func(db *gorm.DB) *gorm.DB {
+		return WithSort("id asc")(WithSort("due_date asc")(db)).Where("task_list_id = ?", taskListID)
+	}
+}
+
+func GetListForTask(ctx context.Context, db *gorm.DB, task Task) (*TaskList, error) {
 	if task.TaskList != nil {
-		return task.TaskList
+		return task.TaskList, nil
 	}
 	if task.TaskListID.Valid {
 		taskList, err := FindOneModel[TaskList](ctx, db, func(db *gorm.DB) *gorm.DB {
 			return db.Where("ID = ?", task.TaskListID.V)
 		})
 		if err != nil {
-			panic(fmt.Sprintf("error loading task list with ID %d: %v", task.TaskListID.V, err))
+			return nil, fmt.Errorf("error loading task list with ID %d: %w", task.TaskListID.V, err)
 		}
-		return taskList
+		return taskList, nil
 	}
-	return nil
+	return nil, nil
 }
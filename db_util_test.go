@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStatusTasksModelQueryOptSortsByDueDate(t *testing.T) {
+	_, db := newTestTaskApp(t)
+	now := time.Now()
+	for _, task := range []Task{
+		{Label: "Later", DueDate: now.Add(2 * time.Hour)},
+		{Label: "Sooner", DueDate: now.Add(time.Hour)},
+	} {
+		createTestTask(t, db, task)
+	}
+
+	tasks, err := FindModel[Task](context.Background(), db, statusTasksModelQueryOpt(TaskStatusTodo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var labels []string
+	for _, task := range tasks {
+		labels = append(labels, task.Label)
+	}
+	if len(labels) != 2 || labels[0] != "Sooner" || labels[1] != "Later" {
+		t.Errorf("got %v, want [Sooner Later]", labels)
+	}
+}
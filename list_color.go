@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+)
+
+const (
+	// listTintAlpha is how opaque a list's color is drawn behind text, so the text stays readable in either variant.
+	listTintAlpha = 96
+)
+
+var (
+	// ListColorNames are the palette colors a TaskList can be given by name, the alternative being a "#rrggbb" hex.
+	ListColorNames = []string{"blue", "purple", "pink", "yellow", "green"}
+
+	listColors = map[string]color.RGBA{
+		"blue":   ColorBlue,
+		"purple": ColorPurple,
+		"pink":   ColorPink,
+		"yellow": ColorYellow,
+		"green":  ColorGreen,
+	}
+)
+
+// NormalizeListColor returns the form a list color is stored in, lower case and trimmed.
+func NormalizeListColor(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// ParseListColor returns the color named by a palette name or "#rrggbb" hex, ok false when s is empty.
+func ParseListColor(s string) (c color.NRGBA, ok bool, err error) {
+	s = NormalizeListColor(s)
+	if s == "" {
+		return c, false, nil
+	}
+	if named, found := listColors[s]; found {
+		return color.NRGBA{R: named.R, G: named.G, B: named.B, A: 255}, true, nil
+	}
+	hex, found := strings.CutPrefix(s, "#")
+	if !found || len(hex) != 6 {
+		return c, false, fmt.Errorf("color %q must be one of %s or a #rrggbb hex", s, strings.Join(ListColorNames, ", "))
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return c, false, fmt.Errorf("color %q is not a valid hex", s)
+	}
+	return color.NRGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}, true, nil
+}
+
+// ValidateListColor is a fyne.StringValidator for list colors.
+func ValidateListColor(s string) error {
+	_, _, err := ParseListColor(s)
+	return err
+}
+
+// AccentColor returns the list's color, ok false when it has none or it can't be read.
+func (tl TaskList) AccentColor() (color.NRGBA, bool) {
+	c, ok, err := ParseListColor(tl.Color)
+	return c, ok && err == nil
+}
+
+// ListColorStripe is the bar drawn down the side of a list's row, nil when the list has no color.
+func ListColorStripe(taskList TaskList) fyne.CanvasObject {
+	c, ok := taskList.AccentColor()
+	if !ok {
+		return nil
+	}
+	stripe := canvas.NewRectangle(c)
+	stripe.SetMinSize(fyne.NewSize(6, 0))
+	return stripe
+}
+
+// ListColorChip is the dot shown next to a task to say which list it is in, nil when the list has no color.
+func ListColorChip(taskList *TaskList) fyne.CanvasObject {
+	if taskList == nil {
+		return nil
+	}
+	c, ok := taskList.AccentColor()
+	if !ok {
+		return nil
+	}
+	return container.NewCenter(container.NewGridWrap(fyne.NewSize(10, 10), canvas.NewCircle(c)))
+}
+
+// ListColorTint puts obj on a translucent background of the list's color, returning obj as is when it has none.
+func ListColorTint(taskList TaskList, obj fyne.CanvasObject) fyne.CanvasObject {
+	c, ok := taskList.AccentColor()
+	if !ok {
+		return obj
+	}
+	c.A = listTintAlpha
+	tint := canvas.NewRectangle(c)
+	tint.CornerRadius = 8
+	return container.NewStack(tint, obj)
+}
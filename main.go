@@ -10,7 +10,10 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"syscall"
+	"time"
 	_ "time/tzdata"
 
 	"fyne.io/fyne/v2"
@@ -23,9 +26,19 @@ var (
 
 func main() {
 	var (
-		logDebug bool
-		dbFile   string
-		err      error
+		logDebug       bool
+		dbFile         string
+		trashRetention time.Duration
+		apiAddr        string
+		apiToken       string
+		pprofAddr      string
+		data           dataFlags
+		migrationStat  bool
+		backups        BackupConfig
+		backupInterval time.Duration
+		startRoute     string
+		resizable      *bool
+		err            error
 	)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
@@ -34,31 +47,69 @@ func main() {
 	flags := flag.NewFlagSet("it488", flag.ContinueOnError)
 	flags.StringVar(&dbFile, "db-file", "it488_team1.db", "Local path to sqlite database file")
 	flags.BoolVar(&logDebug, "debug", false, "Enable debug logging")
+	flags.StringVar(&apiAddr, "api-addr", "", "Listen address for the local JSON API, e.g. 127.0.0.1:6060. Disabled when empty")
+	flags.StringVar(&apiToken, "api-token", os.Getenv(apiTokenEnv), "Bearer token required by the JSON API, defaults to $"+apiTokenEnv+" or a random token")
+	flags.StringVar(&pprofAddr, "pprof-addr", "", "Listen address for the pprof debug server, e.g. 127.0.0.1:6061. Disabled when empty")
+	flags.StringVar(&startRoute, "route", "", "View to open on startup, e.g. today, list/12 or task/40/edit")
+	flags.BoolFunc("resizable", "Let the window be resized, remembered for later starts. Use -resizable=false to undo", func(s string) error {
+		b, err := strconv.ParseBool(s)
+		resizable = &b
+		return err
+	})
+	flags.BoolVar(&migrationStat, "migration-status", false, "Print which schema migrations have been applied to the database, then exit")
+	flags.StringVar(&data.exportJSON, "export-json", "", "Export the database as JSON to this file, or - for stdout, then exit")
+	flags.BoolVar(&data.exportDeleted, "export-deleted", false, "Include trashed lists and tasks in exports")
+	flags.StringVar(&data.importJSON, "import-json", "", "Import a JSON export from this file, or - for stdin, then exit")
+	flags.StringVar(&data.importMode, "import-mode", ImportModeMerge, "How to import: merge adds to existing data, replace removes it first")
+	flags.StringVar(&data.exportTodoTxt, "export-todotxt", "", "Export tasks in todo.txt format to this file, or - for stdout, then exit")
+	flags.StringVar(&data.importTodoTxt, "import-todotxt", "", "Import tasks from a todo.txt file, or - for stdin, then exit")
+	flags.StringVar(&data.todoTxtList, "todotxt-list", "", "Task list ID or label to export, and to import tasks without a +project into")
+	flags.StringVar(&backups.Dir, "backup-dir", "", "Directory to keep database backups in, defaults to a backups directory beside -db-file")
+	flags.IntVar(&backups.Keep, "backup-keep", 7, "Number of database backups to keep, 0 to disable backups")
+	flags.DurationVar(&backupInterval, "backup-interval", 24*time.Hour, "How often to back up the database while the app runs, 0 to only back up on start")
+	flags.DurationVar(&trashRetention, "trash-retention", 30*24*time.Hour, "Permanently delete trashed lists and tasks after this long when the app starts, 0 to keep them forever")
+
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "Usage: it488 [options] [command [command options]]")
+		flags.PrintDefaults()
+		printCLIUsage(flags.Output())
+	}
 
 	if err = flags.Parse(os.Args[1:]); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
-			flags.PrintDefaults()
 			os.Exit(0)
 		}
 		fmt.Print(err.Error())
 		os.Exit(1)
 	}
 
+	// when running a command or export, keep stdout clean for its output.
+	headless := flags.NArg() > 0 || data.requested() || migrationStat
+	logOut := os.Stdout
+	if headless {
+		logOut = os.Stderr
+	}
+
 	logOpts := &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}
 	if logDebug {
 		logOpts.Level = slog.LevelDebug
 	}
-	log = slog.New(slog.NewTextHandler(os.Stdout, logOpts))
+	log = slog.New(slog.NewTextHandler(logOut, logOpts))
 
-	// spin up debug server
-	go func() {
-		if err := http.ListenAndServe("127.0.0.1:6060", nil); err != nil {
-			log.Error(err.Error())
+	if migrationStat {
+		db, err := connectDB(dbFile, logDebug)
+		if err == nil {
+			err = PrintMigrationStatus(db, os.Stdout)
+			tryCloseDB(db)
+		}
+		if err != nil {
+			log.Error("Error reading migration status", "err", err)
 			os.Exit(1)
 		}
-	}()
+		os.Exit(0)
+	}
 
 	db, err := openDB(dbFile, logDebug)
 	if err != nil {
@@ -66,32 +117,115 @@ func main() {
 		os.Exit(1)
 	}
 
-	fyneApp := app.New()
+	backups.DBFile = dbFile
+	backups.LogDebug = logDebug
+	if backups.Dir == "" {
+		backups.Dir = filepath.Join(filepath.Dir(dbFile), "backups")
+	}
+
+	if data.requested() {
+		code := 0
+		if err = data.run(ctx, db); err != nil {
+			log.Error("Error running import or export", "err", err)
+			code = 1
+		}
+		tryCloseDB(db)
+		os.Exit(code)
+	}
+
+	if headless {
+		code := runCLI(ctx, db, flags.Args(), os.Stdout, os.Stderr)
+		tryCloseDB(db)
+		os.Exit(code)
+	}
+
+	dbRef := NewDBRef(db)
+
+	if backups.Enabled() {
+		if path, err := backups.Backup(ctx, db); err != nil {
+			log.Error("Error backing up database", "err", err)
+		} else {
+			log.Debug("Backed up database", "path", path)
+		}
+		if backupInterval > 0 {
+			go backups.ScheduleBackups(ctx, dbRef, backupInterval)
+		}
+	}
+
+	// trash is only purged when the app itself starts, after the backup, never by the command line tools.
+	if trashRetention > 0 {
+		purged, err := PurgeTrash(db, time.Now().Add(-trashRetention))
+		if err != nil {
+			log.Error("Error purging trash", "err", err)
+		} else {
+			log.Debug("Purged trash", "rows", purged, "retention", trashRetention)
+		}
+	}
+
+	// spin up debug server
+	if pprofAddr != "" {
+		go func() {
+			log.Info("Starting pprof server", "addr", pprofAddr)
+			if err := http.ListenAndServe(pprofAddr, nil); err != nil {
+				log.Error(err.Error())
+				os.Exit(1)
+			}
+		}()
+	}
+
+	if apiAddr != "" {
+		if apiToken == "" {
+			if apiToken, err = generateAPIToken(); err != nil {
+				log.Error("Error generating API token", "err", err)
+				os.Exit(1)
+			}
+			log.Info("Generated API token", "token", apiToken)
+		}
+		go func() {
+			if err := NewAPIServer(dbRef, apiToken).ListenAndServe(ctx, apiAddr); err != nil {
+				log.Error("Error running API server", "err", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	fyneApp := app.NewWithID(appID)
 	logAppLifecycle(fyneApp)
 	mainWindow := fyneApp.NewWindow("TODO Today")
 
-	taskApp := newTaskApp(fyneApp, mainWindow, db)
+	taskApp := newTaskApp(fyneApp, mainWindow, dbRef, backups)
 
 	mainWindow.SetContent(taskApp.Container())
 
 	listCount, err := CountModel[TaskList](ctx, taskApp.DB())
 	if err != nil {
-		panic(fmt.Sprintf("Error getting initial task list count: %v", err))
+		log.Error("Error getting initial task list count", "err", err)
+		os.Exit(1)
 	}
 
-	if listCount == 0 {
+	switch {
+	case listCount == 0:
 		taskApp.RenderMutateTaskListView(nil)
-	} else {
-		taskApp.RenderHomeView()
+	case startRoute != "":
+		if err := taskApp.Navigate(startRoute); err != nil {
+			log.Error("Error opening route", "route", startRoute, "err", err)
+			taskApp.RenderHomeView()
+		}
+	default:
+		taskApp.RestoreView()
 	}
 
-	mainWindow.SetFixedSize(true)
-	mainWindow.Resize(fyne.NewSize(400, 700))
+	windowState := LoadWindowState(fyneApp.Preferences())
+	if resizable != nil {
+		windowState.Resizable = *resizable
+		windowState.Save(fyneApp.Preferences())
+	}
+	windowState.Apply(mainWindow)
 
 	// if context is cancelled, close app.
 	go func() {
 		<-ctx.Done()
-		fyneApp.Quit()
+		fyne.Do(taskApp.Quit)
 	}()
 
 	fyne.Do(mainWindow.ShowAndRun)
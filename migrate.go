@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a single, numbered schema change.  Migrations are applied in order and each is recorded in
+// schema_migrations once it succeeds.  Never edit a migration that has been released; add a new one instead.
+//
+// Databases created before migrations existed were built by AutoMigrate and may already contain some of the changes a
+// migration makes, so migrations must tolerate finding their work already done.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+}
+
+// SchemaMigration records an applied Migration.
+type SchemaMigration struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+var migrations = []Migration{
+	{Version: 1, Name: "initial schema", Up: migrateInitialSchema},
+	{Version: 2, Name: "recurrence, subtasks and tags", Up: migrateRecurrenceSubtasksTags},
+	{Version: 3, Name: "full-text search index", Up: migrateSearchIndex},
+	{Version: 4, Name: "rename tasks.priority to sort_order", Up: migrateRenameTaskPriority},
+	{Version: 5, Name: "task list colors", Up: migrateTaskListColors},
+}
+
+// LatestSchemaVersion is the schema version this build of the app expects.
+func LatestSchemaVersion() uint {
+	return migrations[len(migrations)-1].Version
+}
+
+func execMigrationSQL(tx *gorm.DB, stmts ...string) error {
+	for _, stmt := range stmts {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrateInitialSchema(tx *gorm.DB) error {
+	return execMigrationSQL(
+		tx,
+		"CREATE TABLE IF NOT EXISTS `task_lists` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`label` text NOT NULL,`date` datetime,`description` text)",
+		"CREATE INDEX IF NOT EXISTS `idx_task_lists_deleted_at` ON `task_lists`(`deleted_at`)",
+		"CREATE TABLE IF NOT EXISTS `tasks` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`label` text NOT NULL,`description` text,`status` integer NOT NULL DEFAULT 0,`priority` integer NOT NULL,`user_priority` integer NOT NULL DEFAULT 20,`due_date` datetime,`task_list_id` integer,CONSTRAINT `fk_task_lists_tasks` FOREIGN KEY (`task_list_id`) REFERENCES `task_lists`(`id`) ON DELETE CASCADE,CONSTRAINT `uni_tasks_priority` UNIQUE (`priority`))",
+		"CREATE INDEX IF NOT EXISTS `idx_tasks_deleted_at` ON `tasks`(`deleted_at`)",
+	)
+}
+
+func migrateRecurrenceSubtasksTags(tx *gorm.DB) error {
+	if !tx.Migrator().HasColumn("tasks", "recurrence") {
+		if err := tx.Exec("ALTER TABLE `tasks` ADD COLUMN `recurrence` text").Error; err != nil {
+			return err
+		}
+	}
+	return execMigrationSQL(
+		tx,
+		"CREATE TABLE IF NOT EXISTS `subtasks` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`label` text NOT NULL,`done` numeric NOT NULL DEFAULT false,`position` integer NOT NULL DEFAULT 0,`task_id` integer NOT NULL,CONSTRAINT `fk_tasks_subtasks` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE)",
+		"CREATE INDEX IF NOT EXISTS `idx_subtasks_deleted_at` ON `subtasks`(`deleted_at`)",
+		"CREATE TABLE IF NOT EXISTS `tags` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`label` text NOT NULL,CONSTRAINT `uni_tags_label` UNIQUE (`label`))",
+		"CREATE INDEX IF NOT EXISTS `idx_tags_deleted_at` ON `tags`(`deleted_at`)",
+		"CREATE TABLE IF NOT EXISTS `task_tags` (`tag_id` integer,`task_id` integer,PRIMARY KEY (`tag_id`,`task_id`),CONSTRAINT `fk_task_tags_tag` FOREIGN KEY (`tag_id`) REFERENCES `tags`(`id`) ON DELETE CASCADE,CONSTRAINT `fk_task_tags_task` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE)",
+	)
+}
+
+func migrateRenameTaskPriority(tx *gorm.DB) error {
+	if tx.Migrator().HasColumn("tasks", "sort_order") {
+		return nil
+	}
+	return tx.Exec("ALTER TABLE `tasks` RENAME COLUMN `priority` TO `sort_order`").Error
+}
+
+func migrateTaskListColors(tx *gorm.DB) error {
+	if tx.Migrator().HasColumn("task_lists", "color") {
+		return nil
+	}
+	return tx.Exec("ALTER TABLE `task_lists` ADD COLUMN `color` text").Error
+}
+
+// SchemaVersion returns the highest applied migration version, 0 for a database without any.
+func SchemaVersion(db *gorm.DB) (uint, error) {
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return 0, nil
+	}
+	var version uint
+	if err := db.Model(&SchemaMigration{}).Select("coalesce(max(version), 0)").Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("error reading schema version: %w", err)
+	}
+	return version, nil
+}
+
+// migrateDB applies every pending migration in a single transaction.  Databases last written by a newer version of
+// the app are refused, as this version can't know what changed.
+func migrateDB(db *gorm.DB) error {
+	version, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if latest := LatestSchemaVersion(); version > latest {
+		return fmt.Errorf("database schema version %d is newer than this app supports (%d), please upgrade the app", version, latest)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&SchemaMigration{}); err != nil {
+			return fmt.Errorf("error creating schema_migrations table: %w", err)
+		}
+		for _, m := range migrations {
+			if m.Version <= version {
+				continue
+			}
+			log.Info("Applying migration", "version", m.Version, "name", m.Name)
+			if err := m.Up(tx); err != nil {
+				return fmt.Errorf("error applying migration %d (%s): %w", m.Version, m.Name, err)
+			}
+			if err := tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error; err != nil {
+				return fmt.Errorf("error recording migration %d: %w", m.Version, err)
+			}
+		}
+		return nil
+	})
+}
+
+// PrintMigrationStatus writes every known migration and when it was applied, or that it is pending.
+func PrintMigrationStatus(db *gorm.DB, w io.Writer) error {
+	applied := make(map[uint]SchemaMigration)
+	if db.Migrator().HasTable(&SchemaMigration{}) {
+		var rows []SchemaMigration
+		if err := db.Order("version asc").Find(&rows).Error; err != nil {
+			return fmt.Errorf("error reading schema migrations: %w", err)
+		}
+		for _, row := range rows {
+			applied[row.Version] = row
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "VERSION\tAPPLIED\tNAME")
+	for _, m := range migrations {
+		status := "pending"
+		if row, ok := applied[m.Version]; ok {
+			status = row.AppliedAt.Local().Format("2006-01-02 15:04")
+			delete(applied, m.Version)
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\n", m.Version, status, m.Name)
+	}
+	// anything left was applied by a newer version of the app.
+	unknown := slices.Sorted(maps.Keys(applied))
+	for _, version := range unknown {
+		row := applied[version]
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s (unknown to this version)\n", row.Version, row.AppliedAt.Local().Format("2006-01-02 15:04"), row.Name)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"time"
+)
+
+// TaskListJSON is the external representation of a TaskList used by the command line and API.
+type TaskListJSON struct {
+	ID          uint      `json:"id"`
+	Label       string    `json:"label"`
+	Description string    `json:"description"`
+	Color       string    `json:"color,omitempty"`
+	Date        time.Time `json:"date"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewTaskListJSON(taskList TaskList) TaskListJSON {
+	return TaskListJSON{
+		ID:          taskList.ID,
+		Label:       taskList.Label,
+		Description: taskList.Description,
+		Color:       taskList.Color,
+		Date:        taskList.Date,
+		CreatedAt:   taskList.CreatedAt,
+		UpdatedAt:   taskList.UpdatedAt,
+	}
+}
+
+// TaskJSON is the external representation of a Task used by the command line and API.  List and Tags are only
+// filled in when the task was loaded with those associations.
+type TaskJSON struct {
+	ID          uint       `json:"id"`
+	ListID      *uint      `json:"list_id"`
+	List        string     `json:"list,omitempty"`
+	Label       string     `json:"label"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	DueDate     *time.Time `json:"due_date"`
+	Recurrence  string     `json:"recurrence,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func NewTaskJSON(task Task) TaskJSON {
+	out := TaskJSON{
+		ID:          task.ID,
+		Label:       task.Label,
+		Description: task.Description,
+		Status:      TaskStatusTitle(task.Status),
+		Priority:    TaskPriorityName(task.UserPriority),
+		Recurrence:  task.Recurrence,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+	}
+	if task.TaskListID.Valid {
+		listID := uint(task.TaskListID.V)
+		out.ListID = &listID
+	}
+	if task.TaskList != nil {
+		out.List = task.TaskList.Label
+	}
+	if !task.DueDate.IsZero() {
+		dueDate := task.DueDate
+		out.DueDate = &dueDate
+	}
+	for _, tag := range task.Tags {
+		out.Tags = append(out.Tags, tag.Label)
+	}
+	return out
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Routes name views as strings, so they can be restored on startup and opened from the command line.  IDs are those of
+// the task, list or tag shown.
+const (
+	RouteHome       = "home"
+	RouteNavigation = "nav"
+	RouteLists      = "lists"
+	RouteNewList    = "list/new"
+	RouteNewTask    = "task/new"
+	RouteToday      = "today"
+	RouteTags       = "tags"
+	RouteTrash      = "trash"
+	RouteSearch     = "search"
+	RouteData       = "data"
+	RouteBackups    = "backups"
+	RouteSettings   = "settings"
+	RouteCalendar   = "calendar"
+	RouteAgenda     = "agenda"
+
+	// routeMonthFormat and routeDayFormat are how CalendarRoute, DayRoute and AgendaRoute write dates.
+	routeMonthFormat = "2006-01"
+	routeDayFormat   = "2006-01-02"
+
+	routeStatusPrefix = "status/"
+)
+
+var (
+	ErrUnknownRoute = errors.New("unknown route")
+
+	// statusRoutes are the task status filters that can be opened by route, keyed by the last route segment.
+	statusRoutes = map[string]struct {
+		title    string
+		statuses []uint
+	}{
+		"todo":        {"Todo Tasks", []uint{TaskStatusTodo}},
+		"in-progress": {"In Progress Tasks", []uint{TaskStatusInProgress}},
+		"done":        {"Done Tasks", []uint{TaskStatusSkip, TaskStatusDone}},
+	}
+)
+
+func TaskListRoute(id uint) string {
+	return fmt.Sprintf("list/%d", id)
+}
+
+func TaskListTasksRoute(id uint) string {
+	return fmt.Sprintf("list/%d/tasks", id)
+}
+
+func EditTaskListRoute(id uint) string {
+	return fmt.Sprintf("list/%d/edit", id)
+}
+
+// NewTaskInListRoute is the route for creating a task in a list.
+func NewTaskInListRoute(id uint) string {
+	return fmt.Sprintf("list/%d/task/new", id)
+}
+
+func TaskRoute(id uint) string {
+	return fmt.Sprintf("task/%d", id)
+}
+
+func EditTaskRoute(id uint) string {
+	return fmt.Sprintf("task/%d/edit", id)
+}
+
+func TagRoute(id uint) string {
+	return fmt.Sprintf("tag/%d", id)
+}
+
+// StatusRoute is the route of a statusRoutes filter, e.g. "status/todo".
+func StatusRoute(name string) string {
+	return routeStatusPrefix + name
+}
+
+// CalendarRoute is the route of the calendar showing month.
+func CalendarRoute(month time.Time) string {
+	return RouteCalendar + "/" + month.Format(routeMonthFormat)
+}
+
+// DayRoute is the route of the tasks due on day.
+func DayRoute(day time.Time) string {
+	return "day/" + day.Format(routeDayFormat)
+}
+
+// AgendaRoute is the route of the agenda for the week starting on week.
+func AgendaRoute(week time.Time) string {
+	return RouteAgenda + "/" + week.Format(routeDayFormat)
+}
+
+func SearchRoute(query, filter string) string {
+	params := url.Values{}
+	if query != "" {
+		params.Set("q", query)
+	}
+	if filter != "" && filter != searchFilterAll {
+		params.Set("filter", filter)
+	}
+	if len(params) == 0 {
+		return RouteSearch
+	}
+	return RouteSearch + "?" + params.Encode()
+}
+
+// routeUnder returns whether route is parent or one of the routes beneath it, e.g. "task/4/edit" is under "task/4".
+func routeUnder(route, parent string) bool {
+	route, _, _ = strings.Cut(route, "?")
+	return route == parent || strings.HasPrefix(route, parent+"/")
+}
+
+func parseRouteID(s string) (uint, error) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: invalid ID %q", ErrUnknownRoute, s)
+	}
+	return uint(id), nil
+}
+
+func findRouteModel[T any](ctx context.Context, db *gorm.DB, kind, idStr string) (*T, error) {
+	id, err := parseRouteID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	model, err := FindOneModel[T](ctx, db, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s %d: %w", kind, id, err)
+	}
+	if model == nil {
+		return nil, fmt.Errorf("%s %d not found", kind, id)
+	}
+	return model, nil
+}
+
+// viewForRoute builds the view a route names, loading whatever it shows.
+func (ta *TaskApp) viewForRoute(ctx context.Context, route string) (View, error) {
+	path, query, _ := strings.Cut(strings.Trim(strings.TrimSpace(route), "/"), "?")
+	parts := strings.Split(path, "/")
+
+	switch path {
+	case "", RouteHome:
+		return NewHomeView(ta), nil
+	case RouteNavigation:
+		return NewNavigationView(ta), nil
+	case RouteLists:
+		return NewTaskListsView(ta), nil
+	case RouteNewList:
+		return NewMutateTaskListView(ta, nil), nil
+	case RouteNewTask:
+		return NewMutateTaskView(ta, nil, nil), nil
+	case RouteToday:
+		return newTodaysTasksView(ta), nil
+	case RouteTags:
+		return NewTagsView(ta), nil
+	case RouteTrash:
+		return NewTrashView(ta), nil
+	case RouteData:
+		return NewDataView(ta), nil
+	case RouteBackups:
+		return NewBackupsView(ta), nil
+	case RouteSettings:
+		return NewSettingsView(ta), nil
+	case RouteCalendar:
+		return NewCalendarView(ta, time.Now()), nil
+	case RouteAgenda:
+		return NewAgendaView(ta, time.Now()), nil
+	case RouteSearch:
+		params, err := url.ParseQuery(query)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownRoute, err)
+		}
+		return NewSearchView(ta, params.Get("q"), params.Get("filter")), nil
+	}
+
+	switch {
+	case parts[0] == "status" && len(parts) == 2:
+		if _, ok := statusRoutes[parts[1]]; ok {
+			return newStatusTasksView(ta, parts[1]), nil
+		}
+
+	case parts[0] == RouteCalendar && len(parts) == 2:
+		month, err := time.ParseInLocation(routeMonthFormat, parts[1], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid month %q", ErrUnknownRoute, parts[1])
+		}
+		return NewCalendarView(ta, month), nil
+
+	case parts[0] == RouteAgenda && len(parts) == 2:
+		week, err := time.ParseInLocation(routeDayFormat, parts[1], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid week %q", ErrUnknownRoute, parts[1])
+		}
+		return NewAgendaView(ta, week), nil
+
+	case parts[0] == "day" && len(parts) == 2:
+		day, err := time.ParseInLocation(routeDayFormat, parts[1], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid day %q", ErrUnknownRoute, parts[1])
+		}
+		return newDayTasksView(ta, day), nil
+
+	case parts[0] == "list" && len(parts) >= 2:
+		taskList, err := findRouteModel[TaskList](ctx, ta.DB(), "task list", parts[1])
+		if err != nil {
+			return nil, err
+		}
+		switch strings.Join(parts[2:], "/") {
+		case "":
+			return NewTaskListView(ta, *taskList), nil
+		case "tasks":
+			return newTaskListTasksView(ta, *taskList), nil
+		case "edit":
+			return NewMutateTaskListView(ta, taskList), nil
+		case "task/new":
+			return NewMutateTaskView(ta, nil, taskList), nil
+		}
+
+	case parts[0] == "task" && len(parts) >= 2:
+		task, err := findRouteModel[Task](ctx, ta.DB(), "task", parts[1])
+		if err != nil {
+			return nil, err
+		}
+		switch strings.Join(parts[2:], "/") {
+		case "":
+			return NewTaskView(ta, *task), nil
+		case "edit":
+			return NewMutateTaskView(ta, task, nil), nil
+		}
+
+	case parts[0] == "tag" && len(parts) == 2:
+		tag, err := findRouteModel[Tag](ctx, ta.DB(), "tag", parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return newTagTasksView(ta, *tag), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownRoute, route)
+}
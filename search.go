@@ -0,0 +1,237 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	searchIndexTable = "search_index"
+
+	searchKindTask = "task"
+	searchKindList = "list"
+
+	// highlight markers are control characters so they survive markdown escaping of the surrounding text.
+	searchMarkStart = "\x02"
+	searchMarkEnd   = "\x03"
+
+	searchResultLimit = 100
+)
+
+var (
+	// searchIndexTriggers keep search_index in sync with the label and description of tasks and task lists.
+	searchIndexTriggers = []string{
+		searchIndexTriggerSQL("tasks", searchKindTask),
+		searchIndexTriggerSQL("task_lists", searchKindList),
+	}
+)
+
+func searchIndexTriggerSQL(table, kind string) string {
+	return fmt.Sprintf(`
+CREATE TRIGGER IF NOT EXISTS %[1]s_search_insert AFTER INSERT ON %[1]s BEGIN
+	INSERT INTO search_index(kind, ref_id, label, description) VALUES ('%[2]s', new.id, new.label, new.description);
+END;
+CREATE TRIGGER IF NOT EXISTS %[1]s_search_update AFTER UPDATE OF label, description ON %[1]s BEGIN
+	DELETE FROM search_index WHERE kind = '%[2]s' AND ref_id = old.id;
+	INSERT INTO search_index(kind, ref_id, label, description) VALUES ('%[2]s', new.id, new.label, new.description);
+END;
+CREATE TRIGGER IF NOT EXISTS %[1]s_search_delete AFTER DELETE ON %[1]s BEGIN
+	DELETE FROM search_index WHERE kind = '%[2]s' AND ref_id = old.id;
+END;`, table, kind)
+}
+
+// migrateSearchIndex creates the FTS5 search index and its triggers, filling it from existing rows when it is new.
+func migrateSearchIndex(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var exists int64
+		if err := tx.Raw("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?", searchIndexTable).Scan(&exists).Error; err != nil {
+			return fmt.Errorf("error checking for search index: %w", err)
+		}
+
+		if exists == 0 {
+			stmts := []string{
+				"CREATE VIRTUAL TABLE search_index USING fts5(kind UNINDEXED, ref_id UNINDEXED, label, description, tokenize = 'porter unicode61')",
+				"INSERT INTO search_index(kind, ref_id, label, description) SELECT 'task', id, label, description FROM tasks",
+				"INSERT INTO search_index(kind, ref_id, label, description) SELECT 'list', id, label, description FROM task_lists",
+			}
+			for _, stmt := range stmts {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return fmt.Errorf("error creating search index: %w", err)
+				}
+			}
+		}
+
+		for _, stmt := range searchIndexTriggers {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return fmt.Errorf("error creating search index triggers: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// SearchMatchQuery turns user input into an FTS5 match expression, every word being required and matched as a prefix.
+// FTS5 syntax in the input is treated as plain text.  Returns an empty string when there is nothing to search for.
+func SearchMatchQuery(input string) string {
+	var terms []string
+	for _, word := range strings.Fields(input) {
+		word = strings.ReplaceAll(word, `"`, "")
+		if word == "" {
+			continue
+		}
+		terms = append(terms, `"`+word+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// WithSearch limits a Task query to tasks whose label or description match the search input.
+func WithSearch(input string) ModelQueryOpt {
+	return func(db *gorm.DB) *gorm.DB {
+		match := SearchMatchQuery(input)
+		if match == "" {
+			return db.Where("1 = 0")
+		}
+		sub := db.Session(&gorm.Session{NewDB: true}).
+			Table(searchIndexTable).
+			Select("ref_id").
+			Where("kind = ? AND search_index MATCH ?", searchKindTask, match)
+		return db.Where("tasks.id IN (?)", sub)
+	}
+}
+
+// SearchResult is a task or task list matching a search, with the matched words highlighted in Label and Snippet.
+type SearchResult struct {
+	Task     *Task
+	TaskList *TaskList
+
+	// Label and Snippet are markdown, matches being bold.
+	Label   string
+	Snippet string
+	Rank    float64
+}
+
+type searchHit struct {
+	RefID   uint
+	Label   string
+	Snippet string
+	Rank    float64
+}
+
+func searchHits(ctx context.Context, db *gorm.DB, kind, match string) (map[uint]searchHit, error) {
+	var hits []searchHit
+	err := db.WithContext(ctx).Raw(
+		`SELECT ref_id,
+			highlight(search_index, 2, ?, ?) AS label,
+			snippet(search_index, 3, ?, ?, '…', 16) AS snippet,
+			bm25(search_index, 0, 0, 10.0, 1.0) AS rank
+		FROM search_index
+		WHERE kind = ? AND search_index MATCH ?`,
+		searchMarkStart, searchMarkEnd, searchMarkStart, searchMarkEnd, kind, match,
+	).Scan(&hits).Error
+	if err != nil {
+		return nil, fmt.Errorf("error searching: %w", err)
+	}
+
+	out := make(map[uint]searchHit, len(hits))
+	for _, hit := range hits {
+		out[hit.RefID] = hit
+	}
+	return out, nil
+}
+
+// searchMarkdown escapes highlighted text for markdown and turns the highlight markers into bold.
+func searchMarkdown(s string) string {
+	s = markdownEscaper.Replace(strings.Join(strings.Fields(s), " "))
+	return strings.NewReplacer(searchMarkStart, "**", searchMarkEnd, "**").Replace(s)
+}
+
+func newSearchResult(hit searchHit) SearchResult {
+	res := SearchResult{
+		Label: searchMarkdown(hit.Label),
+		Rank:  hit.Rank,
+	}
+	// only show the description when it is where the match was.
+	if strings.Contains(hit.Snippet, searchMarkStart) {
+		res.Snippet = searchMarkdown(hit.Snippet)
+	}
+	return res
+}
+
+// limitSearchResults keeps the best matches.  It is applied after filtering so that rows filtered out, trashed ones
+// included, don't take the place of ones that would be shown.
+func limitSearchResults(results []SearchResult) []SearchResult {
+	if len(results) > searchResultLimit {
+		return results[:searchResultLimit]
+	}
+	return results
+}
+
+// SearchTasks returns the tasks matching the search input, best match first.  opts further filter the tasks, so
+// search can be combined with the usual status and due date filters.
+func SearchTasks(ctx context.Context, db *gorm.DB, input string, opts ...ModelQueryOpt) ([]SearchResult, error) {
+	match := SearchMatchQuery(input)
+	if match == "" {
+		return nil, nil
+	}
+
+	hits, err := searchHits(ctx, db, searchKindTask, match)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := FindModel[Task](ctx, db, append(opts, WithSearch(input), WithPreload("TaskList"))...)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching matching tasks: %w", err)
+	}
+
+	out := make([]SearchResult, 0, len(tasks))
+	for i := range tasks {
+		res := newSearchResult(hits[tasks[i].ID])
+		res.Task = &tasks[i]
+		out = append(out, res)
+	}
+	slices.SortStableFunc(out, func(a, b SearchResult) int {
+		return cmp.Compare(a.Rank, b.Rank)
+	})
+	return limitSearchResults(out), nil
+}
+
+// SearchTaskLists returns the task lists matching the search input, best match first.
+func SearchTaskLists(ctx context.Context, db *gorm.DB, input string) ([]SearchResult, error) {
+	match := SearchMatchQuery(input)
+	if match == "" {
+		return nil, nil
+	}
+
+	hits, err := searchHits(ctx, db, searchKindList, match)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(hits))
+	for id := range hits {
+		ids = append(ids, id)
+	}
+
+	taskLists, err := FindModel[TaskList](ctx, db, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id IN ?", ids)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching matching task lists: %w", err)
+	}
+
+	out := make([]SearchResult, 0, len(taskLists))
+	for i := range taskLists {
+		res := newSearchResult(hits[taskLists[i].ID])
+		res.TaskList = &taskLists[i]
+		out = append(out, res)
+	}
+	slices.SortStableFunc(out, func(a, b SearchResult) int {
+		return cmp.Compare(a.Rank, b.Rank)
+	})
+	return limitSearchResults(out), nil
+}
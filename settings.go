@@ -0,0 +1,141 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	prefDefaultPriority = "settings.default_priority"
+	prefDefaultTaskList = "settings.default_task_list_id"
+	prefDefaultDue      = "settings.default_due"
+	prefDateFormat      = "settings.date_format"
+	prefTimeFormat      = "settings.time_format"
+	prefFirstWeekday    = "settings.first_weekday"
+	prefThemeVariant    = "settings.theme_variant"
+
+	DefaultDueNow      = "Now"
+	DefaultDueEndOfDay = "End of today"
+	DefaultDueTomorrow = "Tomorrow morning"
+	DefaultDueNextWeek = "In a week"
+)
+
+var (
+	DefaultDueOptions = []string{DefaultDueNow, DefaultDueEndOfDay, DefaultDueTomorrow, DefaultDueNextWeek}
+
+	// DateFormatOptions and TimeFormatOptions are the layouts dates and times can be displayed with, the first of each
+	// being the default.
+	DateFormatOptions = []string{"Jan _2", "Jan _2 2006", "Mon Jan _2", "2006-01-02", "02/01/2006", "01/02/2006"}
+	TimeFormatOptions = []string{"3:04:05PM", "3:04PM", "15:04:05", "15:04"}
+
+	FirstWeekdayOptions = []time.Weekday{time.Sunday, time.Monday, time.Saturday}
+)
+
+// Settings are the user's preferences for how the app behaves.
+type Settings struct {
+	// DefaultPriority is a TaskPriorities entry given to new tasks.
+	DefaultPriority string
+	// DefaultTaskListID is the list new tasks go in when not created from within a list, 0 for the last viewed list.
+	DefaultTaskListID uint
+	// DefaultDue is a DefaultDueOptions entry picking the due date of new tasks.
+	DefaultDue   string
+	DateFormat   string
+	TimeFormat   string
+	FirstWeekday time.Weekday
+	// ThemeVariant is a ThemeVariantOptions entry, whether to follow the OS's light or dark variant or override it.
+	ThemeVariant string
+}
+
+// LoadSettings reads the settings, falling back to the defaults for any missing or no longer valid.
+func LoadSettings(prefs fyne.Preferences) Settings {
+	s := Settings{
+		DefaultPriority:   prefs.StringWithFallback(prefDefaultPriority, strings.ToTitle(TaskPriorityHigh)),
+		DefaultTaskListID: uint(max(prefs.Int(prefDefaultTaskList), 0)),
+		DefaultDue:        prefs.StringWithFallback(prefDefaultDue, DefaultDueNow),
+		DateFormat:        prefs.StringWithFallback(prefDateFormat, DateFormatOptions[0]),
+		TimeFormat:        prefs.StringWithFallback(prefTimeFormat, TimeFormatOptions[0]),
+		FirstWeekday:      time.Weekday(prefs.IntWithFallback(prefFirstWeekday, int(time.Sunday))),
+		ThemeVariant:      prefs.StringWithFallback(prefThemeVariant, ThemeVariantSystem),
+	}
+	if !slices.Contains(TaskPriorities, s.DefaultPriority) {
+		s.DefaultPriority = strings.ToTitle(TaskPriorityHigh)
+	}
+	if !slices.Contains(DefaultDueOptions, s.DefaultDue) {
+		s.DefaultDue = DefaultDueNow
+	}
+	if !slices.Contains(DateFormatOptions, s.DateFormat) {
+		s.DateFormat = DateFormatOptions[0]
+	}
+	if !slices.Contains(TimeFormatOptions, s.TimeFormat) {
+		s.TimeFormat = TimeFormatOptions[0]
+	}
+	if !slices.Contains(FirstWeekdayOptions, s.FirstWeekday) {
+		s.FirstWeekday = time.Sunday
+	}
+	if !slices.Contains(ThemeVariantOptions, s.ThemeVariant) {
+		s.ThemeVariant = ThemeVariantSystem
+	}
+	return s
+}
+
+func (s Settings) Save(prefs fyne.Preferences) {
+	prefs.SetString(prefDefaultPriority, s.DefaultPriority)
+	prefs.SetInt(prefDefaultTaskList, int(s.DefaultTaskListID))
+	prefs.SetString(prefDefaultDue, s.DefaultDue)
+	prefs.SetString(prefDateFormat, s.DateFormat)
+	prefs.SetString(prefTimeFormat, s.TimeFormat)
+	prefs.SetInt(prefFirstWeekday, int(s.FirstWeekday))
+	prefs.SetString(prefThemeVariant, s.ThemeVariant)
+}
+
+// DueDate returns the due date a new task created at now starts with.
+func (s Settings) DueDate(now time.Time) time.Time {
+	switch s.DefaultDue {
+	case DefaultDueEndOfDay:
+		return StartOfDay(now).Add(24*time.Hour - time.Minute)
+	case DefaultDueTomorrow:
+		return StartOfDay(now).AddDate(0, 0, 1).Add(9 * time.Hour)
+	case DefaultDueNextWeek:
+		return now.AddDate(0, 0, 7)
+	default:
+		return now
+	}
+}
+
+func (s Settings) DisplayFormat() DisplayFormat {
+	return DisplayFormat{Date: s.DateFormat, Time: s.TimeFormat}
+}
+
+// Settings returns the current settings.
+func (ta *TaskApp) Settings() Settings {
+	return LoadSettings(ta.fyneApp.Preferences())
+}
+
+// defaultTaskList returns the list from taskLists that new tasks go in by default, the one chosen in the settings or
+// else the most recently viewed, or nil when neither is in taskLists.
+func (ta *TaskApp) defaultTaskList(taskLists []TaskList) *TaskList {
+	for _, id := range []uint{ta.Settings().DefaultTaskListID, ta.SelectedTaskListID()} {
+		if id == 0 {
+			continue
+		}
+		for i := range taskLists {
+			if taskLists[i].ID == id {
+				return &taskLists[i]
+			}
+		}
+	}
+	return nil
+}
+
+// SaveSettings stores the settings and applies those that take effect immediately.
+func (ta *TaskApp) SaveSettings(s Settings) {
+	s.Save(ta.fyneApp.Preferences())
+	SetDisplayFormat(s.DisplayFormat())
+	if ta.theme.SetVariant(s.ThemeVariant) {
+		// setting the theme again has fyne redraw everything, calling applyTheme.
+		ta.fyneApp.Settings().SetTheme(ta.theme)
+	}
+}
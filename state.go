@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	appID = "com.github.dcarbone.IT488"
+
+	prefLastRoute       = "state.last_route"
+	prefLastTaskList    = "state.last_task_list_id"
+	prefWindowWidth     = "window.width"
+	prefWindowHeight    = "window.height"
+	prefWindowResizable = "window.resizable"
+
+	defaultWindowWidth  = 400
+	defaultWindowHeight = 700
+)
+
+// WindowState is the size of the main window, kept across restarts.  Fyne has no way to read or set a window's
+// position, so it is left to the window manager.
+type WindowState struct {
+	Size      fyne.Size
+	Resizable bool
+}
+
+func LoadWindowState(prefs fyne.Preferences) WindowState {
+	return WindowState{
+		Size: fyne.NewSize(
+			float32(prefs.FloatWithFallback(prefWindowWidth, defaultWindowWidth)),
+			float32(prefs.FloatWithFallback(prefWindowHeight, defaultWindowHeight)),
+		),
+		Resizable: prefs.Bool(prefWindowResizable),
+	}
+}
+
+func (s WindowState) Save(prefs fyne.Preferences) {
+	prefs.SetFloat(prefWindowWidth, float64(s.Size.Width))
+	prefs.SetFloat(prefWindowHeight, float64(s.Size.Height))
+	prefs.SetBool(prefWindowResizable, s.Resizable)
+}
+
+// Apply sizes the window, which only the user can resize when it is resizable.
+func (s WindowState) Apply(window fyne.Window) {
+	window.SetFixedSize(!s.Resizable)
+	window.Resize(s.Size)
+}
+
+// routeTaskListID returns the ID of the task list a route is within, 0 when it isn't within one.
+func routeTaskListID(route string) uint {
+	rest, ok := strings.CutPrefix(route, "list/")
+	if !ok {
+		return 0
+	}
+	idStr, _, _ := strings.Cut(rest, "/")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
+
+// rememberView records the route being shown, and the list it is within, so they can be restored on the next start.
+func (ta *TaskApp) rememberView(view View) {
+	if _, ok := view.(*NavigationView); ok {
+		return
+	}
+	route := view.Route()
+	prefs := ta.fyneApp.Preferences()
+	prefs.SetString(prefLastRoute, route)
+	if id := routeTaskListID(route); id != 0 {
+		prefs.SetInt(prefLastTaskList, int(id))
+	}
+}
+
+// SelectedTaskListID returns the ID of the task list most recently viewed, 0 when there is none.
+func (ta *TaskApp) SelectedTaskListID() uint {
+	return uint(max(ta.fyneApp.Preferences().Int(prefLastTaskList), 0))
+}
+
+// RestoreView opens the view that was showing when the app last quit.  When what it showed no longer exists, the
+// last viewed list is opened instead, and failing that the home view.
+func (ta *TaskApp) RestoreView() {
+	route := ta.fyneApp.Preferences().String(prefLastRoute)
+	if route == "" {
+		ta.RenderHomeView()
+		return
+	}
+
+	err := ta.Navigate(route)
+	if err == nil {
+		return
+	}
+	log.Warn("Unable to restore last view", "route", route, "err", err)
+
+	if id := ta.SelectedTaskListID(); id != 0 {
+		if err = ta.Navigate(TaskListTasksRoute(id)); err == nil {
+			return
+		}
+		ta.fyneApp.Preferences().RemoveValue(prefLastTaskList)
+	}
+	ta.RenderHomeView()
+}
+
+// Quit saves the window state and quits the app.
+func (ta *TaskApp) Quit() {
+	state := LoadWindowState(ta.fyneApp.Preferences())
+	state.Size = ta.window.Canvas().Size()
+	state.Save(ta.fyneApp.Preferences())
+	ta.fyneApp.Quit()
+}
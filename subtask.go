@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
+)
+
+func taskSubtasksModelQueryOpt(taskID uint) ModelQueryOpt {
+	return func(db *gorm.DB) *gorm.DB {
+		return WithSort("id asc")(WithSort("position asc")(db)).
+			Where("task_id = ?", taskID)
+	}
+}
+
+// SaveSubtasks makes the stored checklist of a task match items: missing items are removed, existing ones updated
+// and new ones (ID 0) created, all positioned in slice order.
+func SaveSubtasks(db *gorm.DB, taskID uint, items []Subtask) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		keep := make([]uint, 0, len(items))
+		for i := range items {
+			if items[i].ID != 0 {
+				keep = append(keep, items[i].ID)
+			}
+		}
+
+		rm := tx.Unscoped().Where("task_id = ?", taskID)
+		if len(keep) > 0 {
+			rm = rm.Where("id NOT IN ?", keep)
+		}
+		if err := rm.Delete(&Subtask{}).Error; err != nil {
+			return fmt.Errorf("error removing subtasks from task %d: %w", taskID, err)
+		}
+
+		for i := range items {
+			items[i].TaskID = taskID
+			items[i].Position = uint(i)
+			var res *gorm.DB
+			if items[i].ID == 0 {
+				res = tx.Create(&items[i])
+			} else {
+				res = tx.Model(&items[i]).Select("Label", "Done", "Position").Updates(&items[i])
+			}
+			if res.Error != nil {
+				return fmt.Errorf("error saving subtask %q on task %d: %w", items[i].Label, taskID, res.Error)
+			}
+		}
+
+		return nil
+	})
+}
+
+// newSubtaskChecklist renders a task's checklist, persisting each tick as it happens.
+func newSubtaskChecklist(app *TaskApp, items []Subtask) fyne.CanvasObject {
+	checklist := container.NewVBox()
+	for i := range items {
+		item := &items[i]
+		check := widget.NewCheck(item.Label, nil)
+		check.SetChecked(item.Done)
+		var save func(b bool)
+		save = func(b bool) {
+			res := app.DB().Model(item).Update("Done", b)
+			if res.Error != nil {
+				app.ReportError(fmt.Sprintf("Error updating %s", item.Label), res.Error, func() { save(b) })
+				return
+			}
+			description := fmt.Sprintf("Ticked %s", item.Label)
+			if !b {
+				description = fmt.Sprintf("Unticked %s", item.Label)
+			}
+			app.RecordUndo(description, func(db *gorm.DB) error {
+				return db.Model(item).Update("Done", !b).Error
+			})
+		}
+		check.OnChanged = save
+		checklist.Add(check)
+	}
+	return checklist
+}
+
+// newSubtaskEditor builds the checklist inputs used by MutateTaskView.  The returned func produces the edited items,
+// in order, ready for SaveSubtasks.
+func newSubtaskEditor(items []Subtask) (fyne.CanvasObject, func() []Subtask) {
+	type editorRow struct {
+		subtask Subtask
+		check   *widget.Check
+		entry   *widget.Entry
+	}
+
+	var (
+		rows      = make([]*editorRow, 0, len(items))
+		rowsBox   = container.NewVBox()
+		renderRow func(row *editorRow)
+	)
+
+	renderRows := func() {
+		rowsBox.RemoveAll()
+		for _, row := range rows {
+			renderRow(row)
+		}
+	}
+
+	renderRow = func(row *editorRow) {
+		rowsBox.Add(container.NewBorder(
+			nil,
+			nil,
+			row.check,
+			widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				for i := range rows {
+					if rows[i] == row {
+						rows = append(rows[:i], rows[i+1:]...)
+						break
+					}
+				}
+				renderRows()
+			}),
+			row.entry,
+		))
+	}
+
+	addRow := func(st Subtask) {
+		row := editorRow{
+			subtask: st,
+			check:   widget.NewCheck("", nil),
+			entry:   widget.NewEntry(),
+		}
+		row.check.SetChecked(st.Done)
+		row.entry.SetText(st.Label)
+		rows = append(rows, &row)
+		renderRow(&row)
+	}
+
+	for _, st := range items {
+		addRow(st)
+	}
+
+	newInput := widget.NewEntry()
+	newInput.PlaceHolder = "New checklist item"
+	addNew := func() {
+		label := strings.TrimSpace(newInput.Text)
+		if label == "" {
+			return
+		}
+		addRow(Subtask{Label: label})
+		newInput.SetText("")
+	}
+	newInput.OnSubmitted = func(string) { addNew() }
+
+	value := func() []Subtask {
+		out := make([]Subtask, 0, len(rows))
+		for _, row := range rows {
+			label := strings.TrimSpace(row.entry.Text)
+			if label == "" {
+				continue
+			}
+			st := row.subtask
+			st.Label = label
+			st.Done = row.check.Checked
+			out = append(out, st)
+		}
+		return out
+	}
+
+	return container.NewVBox(
+		rowsBox,
+		container.NewBorder(
+			nil,
+			nil,
+			nil,
+			widget.NewButtonWithIcon("", theme.ContentAddIcon(), addNew),
+			newInput,
+		),
+	), value
+}
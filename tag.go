@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
+)
+
+// ParseTagLabels splits comma separated input into unique, trimmed tag labels.
+func ParseTagLabels(s string) []string {
+	out := make([]string, 0)
+	for _, label := range strings.Split(s, ",") {
+		label = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(label), "#"))
+		if label == "" {
+			continue
+		}
+		if !slices.ContainsFunc(out, func(l string) bool { return strings.EqualFold(l, label) }) {
+			out = append(out, label)
+		}
+	}
+	return out
+}
+
+// FindOrCreateTags returns the tags with the given labels, matched case-insensitively, creating any that don't exist.
+func FindOrCreateTags(db *gorm.DB, labels []string) ([]Tag, error) {
+	tags := make([]Tag, 0, len(labels))
+	for _, label := range labels {
+		var tag Tag
+		res := db.Where("lower(label) = lower(?)", label).Limit(1).Find(&tag)
+		if res.Error != nil {
+			return nil, fmt.Errorf("error finding tag %q: %w", label, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			tag = Tag{Label: label}
+			if err := db.Create(&tag).Error; err != nil {
+				return nil, fmt.Errorf("error creating tag %q: %w", label, err)
+			}
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// SetTaskTags replaces the tags on a task with the given labels.
+func SetTaskTags(db *gorm.DB, taskID uint, labels []string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		tags, err := FindOrCreateTags(tx, labels)
+		if err != nil {
+			return err
+		}
+		task := Task{Model: gorm.Model{ID: taskID}}
+		if err = tx.Model(&task).Association("Tags").Replace(tags); err != nil {
+			return fmt.Errorf("error setting tags on task %d: %w", taskID, err)
+		}
+		return nil
+	})
+}
+
+func tagTasksModelQueryOpt(tagID uint) ModelQueryOpt {
+	return func(db *gorm.DB) *gorm.DB {
+		tagged := db.Session(&gorm.Session{NewDB: true}).
+			Table("task_tags").
+			Select("task_id").
+			Where("tag_id = ?", tagID)
+		return WithSort("id asc")(WithSort("due_date asc")(WithPreload("TaskList")(db))).
+			Where("`tasks`.`id` IN (?)", tagged)
+	}
+}
+
+// newTagPicker builds the tag inputs used by MutateTaskView: a check for every known tag plus an entry for new ones.
+// The returned func produces the chosen labels.
+func newTagPicker(allTags []Tag, selected []Tag) (fyne.CanvasObject, func() []string) {
+	labels := make([]string, len(allTags))
+	for i := range allTags {
+		labels[i] = allTags[i].Label
+	}
+
+	tagCheck := widget.NewCheckGroup(labels, nil)
+	chosen := make([]string, 0, len(selected))
+	for _, tag := range selected {
+		chosen = append(chosen, tag.Label)
+	}
+	tagCheck.SetSelected(chosen)
+
+	newInput := widget.NewEntry()
+	newInput.PlaceHolder = "New tags, comma separated"
+
+	value := func() []string {
+		return ParseTagLabels(strings.Join(append(slices.Clone(tagCheck.Selected), newInput.Text), ","))
+	}
+
+	return container.NewVBox(tagCheck, newInput), value
+}
+
+// newTagChips renders a task's tags as buttons which open the tasks sharing that tag.
+func newTagChips(app *TaskApp, tags []Tag) fyne.CanvasObject {
+	chips := container.NewGridWrap(fyne.NewSize(110, 36))
+	for _, tag := range tags {
+		chip := widget.NewButton("#"+tag.Label, func() {
+			app.RenderTagTasksView(tag)
+		})
+		chip.Importance = widget.LowImportance
+		chips.Add(chip)
+	}
+	return chips
+}
@@ -66,6 +66,16 @@ func TaskPriorityNumber(priority string) uint {
 	}
 }
 
+// ParseTaskPriority is the strict form of TaskPriorityNumber, erroring on unknown priority names.
+func ParseTaskPriority(priority string) (uint, error) {
+	for _, name := range TaskPriorities {
+		if strings.EqualFold(name, strings.TrimSpace(priority)) {
+			return TaskPriorityNumber(strings.ToLower(name)), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown task priority %q, expected one of %s", priority, strings.ToLower(strings.Join(TaskPriorities, ", ")))
+}
+
 func TaskPriorityName(priority uint) string {
 	switch priority {
 	case 0:
@@ -114,25 +124,34 @@ func TaskPriorityResource(priority string) *fyne.StaticResource {
 	}
 }
 
-func newTaskPrioritySwitcherButton(db *gorm.DB, task *Task) *widget.Button {
+func newTaskPrioritySwitcherButton(app *TaskApp, task *Task) *widget.Button {
 	var priorityButton *widget.Button
 	priorityIdx := slices.Index(TaskPriorities, strings.ToTitle(TaskPriorityName(task.UserPriority)))
-	priorityButton = widget.NewButtonWithIcon(
-		"",
-		TaskPriorityResource(TaskPriorityName(task.UserPriority)),
-		func() {
-			priorityIdx++
-			if priorityIdx == len(TaskPriorities) {
-				priorityIdx = 0
-			}
-			task.UserPriority = TaskPriorityNumber(TaskPriorities[priorityIdx])
-			res := db.Model(&task).Update("UserPriority", task.UserPriority)
-			if res.Error != nil {
-				panic(fmt.Sprintf("error updating task user priority: %v", res.Error))
-			}
-			priorityButton.SetIcon(TaskPriorityResource(TaskPriorities[priorityIdx]))
-		},
-	)
+	var cycle func()
+	cycle = func() {
+		nextIdx := (priorityIdx + 1) % len(TaskPriorities)
+		previous := task.UserPriority
+		priority := TaskPriorityNumber(TaskPriorities[nextIdx])
+		res := app.DB().Model(task).Update("UserPriority", priority)
+		if res.Error != nil {
+			app.ReportError(fmt.Sprintf("Error updating the priority of %s", task.Label), res.Error, cycle)
+			return
+		}
+		priorityIdx = nextIdx
+		task.UserPriority = priority
+		app.RecordUndo(
+			fmt.Sprintf("%s set to %s priority", task.Label, TaskPriorityName(task.UserPriority)),
+			func(db *gorm.DB) error {
+				if err := db.Model(task).Update("UserPriority", previous).Error; err != nil {
+					return err
+				}
+				task.UserPriority = previous
+				return nil
+			},
+		)
+		priorityButton.SetIcon(TaskPriorityResource(TaskPriorities[priorityIdx]))
+	}
+	priorityButton = widget.NewButtonWithIcon("", TaskPriorityResource(TaskPriorityName(task.UserPriority)), cycle)
 	priorityButton.Importance = widget.LowImportance
 
 	return priorityButton
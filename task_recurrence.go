@@ -0,0 +1,690 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
+)
+
+const (
+	TaskRecurrenceTitleNever     = "Never"
+	TaskRecurrenceTitleDaily     = "Daily"
+	TaskRecurrenceTitleWeekdays  = "Weekdays"
+	TaskRecurrenceTitleWeekly    = "Weekly"
+	TaskRecurrenceTitleMonthly   = "Monthly"
+	TaskRecurrenceTitleEveryNDay = "Every N days"
+	TaskRecurrenceTitleCustom    = "Custom (RRULE)"
+
+	TaskRecurrenceFreqDaily   = "DAILY"
+	TaskRecurrenceFreqWeekly  = "WEEKLY"
+	TaskRecurrenceFreqMonthly = "MONTHLY"
+	TaskRecurrenceFreqYearly  = "YEARLY"
+
+	// taskRecurrenceMaxIterations bounds the search for the next occurrence so a rule that can never match does
+	// not spin forever.
+	taskRecurrenceMaxIterations = 10000
+)
+
+var (
+	TaskRecurrenceTitles = []string{
+		TaskRecurrenceTitleNever,
+		TaskRecurrenceTitleDaily,
+		TaskRecurrenceTitleWeekdays,
+		TaskRecurrenceTitleWeekly,
+		TaskRecurrenceTitleMonthly,
+		TaskRecurrenceTitleEveryNDay,
+		TaskRecurrenceTitleCustom,
+	}
+
+	taskRecurrenceWeekdayCodes = map[string]time.Weekday{
+		"SU": time.Sunday,
+		"MO": time.Monday,
+		"TU": time.Tuesday,
+		"WE": time.Wednesday,
+		"TH": time.Thursday,
+		"FR": time.Friday,
+		"SA": time.Saturday,
+	}
+
+	// taskRecurrenceWeekdays is the order weekdays are presented in and written out in.
+	taskRecurrenceWeekdays = []time.Weekday{
+		time.Monday,
+		time.Tuesday,
+		time.Wednesday,
+		time.Thursday,
+		time.Friday,
+		time.Saturday,
+		time.Sunday,
+	}
+
+	taskRecurrenceWorkWeek = []time.Weekday{
+		time.Monday,
+		time.Tuesday,
+		time.Wednesday,
+		time.Thursday,
+		time.Friday,
+	}
+)
+
+func taskRecurrenceWeekdayCode(day time.Weekday) string {
+	return strings.ToUpper(day.String()[:2])
+}
+
+// TaskRecurrenceByDay is a single BYDAY entry.  Ordinal is only meaningful for MONTHLY rules, where "1MO" is the
+// first Monday of the month and "-1FR" is the last Friday.
+type TaskRecurrenceByDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+func (bd TaskRecurrenceByDay) String() string {
+	if bd.Ordinal == 0 {
+		return taskRecurrenceWeekdayCode(bd.Weekday)
+	}
+	return fmt.Sprintf("%d%s", bd.Ordinal, taskRecurrenceWeekdayCode(bd.Weekday))
+}
+
+// TaskRecurrence is the subset of RFC 5545 RRULE supported for recurring tasks.
+type TaskRecurrence struct {
+	Freq       string
+	Interval   int
+	ByDay      []TaskRecurrenceByDay
+	ByMonthDay []int
+
+	// Count is the number of occurrences remaining, including the current one.  Zero means unbounded.
+	Count int
+	Until time.Time
+}
+
+func ParseTaskRecurrence(rule string) (*TaskRecurrence, error) {
+	rule = strings.TrimSpace(rule)
+	rule = strings.TrimPrefix(strings.ToUpper(rule), "RRULE:")
+	if rule == "" {
+		return nil, errors.New("recurrence rule is empty")
+	}
+
+	r := TaskRecurrence{Interval: 1}
+
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(part, "=")
+		if !ok || v == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		switch k {
+		case "FREQ":
+			switch v {
+			case TaskRecurrenceFreqDaily, TaskRecurrenceFreqWeekly, TaskRecurrenceFreqMonthly, TaskRecurrenceFreqYearly:
+				r.Freq = v
+			default:
+				return nil, fmt.Errorf("unsupported recurrence frequency %q", v)
+			}
+
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid recurrence interval %q", v)
+			}
+			r.Interval = n
+
+		case "BYDAY":
+			for _, day := range strings.Split(v, ",") {
+				bd, err := parseTaskRecurrenceByDay(day)
+				if err != nil {
+					return nil, err
+				}
+				r.ByDay = append(r.ByDay, bd)
+			}
+
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(v, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid recurrence month day %q", day)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid recurrence count %q", v)
+			}
+			r.Count = n
+
+		case "UNTIL":
+			until, err := parseTaskRecurrenceUntil(v)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = until
+
+		case "WKST":
+			// weeks always start on Monday for the purposes of INTERVAL.
+
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", k)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, errors.New("recurrence rule is missing FREQ")
+	}
+	if r.Freq != TaskRecurrenceFreqMonthly {
+		for _, bd := range r.ByDay {
+			if bd.Ordinal != 0 {
+				return nil, fmt.Errorf("BYDAY ordinal %q is only supported with FREQ=MONTHLY", bd.String())
+			}
+		}
+	}
+
+	return &r, nil
+}
+
+func parseTaskRecurrenceByDay(day string) (TaskRecurrenceByDay, error) {
+	day = strings.TrimSpace(day)
+	if len(day) < 2 {
+		return TaskRecurrenceByDay{}, fmt.Errorf("invalid recurrence weekday %q", day)
+	}
+	wd, ok := taskRecurrenceWeekdayCodes[day[len(day)-2:]]
+	if !ok {
+		return TaskRecurrenceByDay{}, fmt.Errorf("invalid recurrence weekday %q", day)
+	}
+	bd := TaskRecurrenceByDay{Weekday: wd}
+	if ord := day[:len(day)-2]; ord != "" {
+		n, err := strconv.Atoi(ord)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return TaskRecurrenceByDay{}, fmt.Errorf("invalid recurrence weekday %q", day)
+		}
+		bd.Ordinal = n
+	}
+	return bd, nil
+}
+
+func parseTaskRecurrenceUntil(v string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		loc := time.Local
+		if strings.HasSuffix(layout, "Z") {
+			loc = time.UTC
+		}
+		if tm, err := time.ParseInLocation(layout, v, loc); err == nil {
+			if layout == "20060102" {
+				// a bare date includes the whole day.
+				tm = tm.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			return tm, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid recurrence until %q", v)
+}
+
+func (r TaskRecurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i := range r.ByDay {
+			days[i] = r.ByDay[i].String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i := range r.ByMonthDay {
+			days[i] = strconv.Itoa(r.ByMonthDay[i])
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (r TaskRecurrence) weekdays() []time.Weekday {
+	out := make([]time.Weekday, 0, len(r.ByDay))
+	for _, bd := range r.ByDay {
+		if bd.Ordinal == 0 && !slices.Contains(out, bd.Weekday) {
+			out = append(out, bd.Weekday)
+		}
+	}
+	return out
+}
+
+func (r TaskRecurrence) isWorkWeek() bool {
+	days := r.weekdays()
+	if len(days) != len(r.ByDay) || len(days) != len(taskRecurrenceWorkWeek) {
+		return false
+	}
+	for _, wd := range taskRecurrenceWorkWeek {
+		if !slices.Contains(days, wd) {
+			return false
+		}
+	}
+	return true
+}
+
+// Title returns the entry in TaskRecurrenceTitles that best describes this rule.
+func (r TaskRecurrence) Title() string {
+	if r.Count > 0 || !r.Until.IsZero() {
+		return TaskRecurrenceTitleCustom
+	}
+	switch r.Freq {
+	case TaskRecurrenceFreqDaily:
+		if len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 {
+			return TaskRecurrenceTitleCustom
+		}
+		if r.Interval == 1 {
+			return TaskRecurrenceTitleDaily
+		}
+		return TaskRecurrenceTitleEveryNDay
+	case TaskRecurrenceFreqWeekly:
+		if r.Interval != 1 || len(r.ByMonthDay) > 0 {
+			return TaskRecurrenceTitleCustom
+		}
+		if r.isWorkWeek() {
+			return TaskRecurrenceTitleWeekdays
+		}
+		return TaskRecurrenceTitleWeekly
+	case TaskRecurrenceFreqMonthly:
+		if r.Interval == 1 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 1 {
+			return TaskRecurrenceTitleMonthly
+		}
+	}
+	return TaskRecurrenceTitleCustom
+}
+
+// Describe returns a short human-readable summary of the rule.
+func (r TaskRecurrence) Describe() string {
+	switch r.Title() {
+	case TaskRecurrenceTitleDaily:
+		return "Every day"
+	case TaskRecurrenceTitleEveryNDay:
+		return fmt.Sprintf("Every %d days", r.Interval)
+	case TaskRecurrenceTitleWeekdays:
+		return "Every weekday"
+	case TaskRecurrenceTitleWeekly:
+		if len(r.ByDay) == 0 {
+			return "Every week"
+		}
+		days := make([]string, len(r.ByDay))
+		for i := range r.ByDay {
+			days[i] = r.ByDay[i].Weekday.String()[:3]
+		}
+		return fmt.Sprintf("Every week on %s", strings.Join(days, ", "))
+	case TaskRecurrenceTitleMonthly:
+		if r.ByMonthDay[0] < 0 {
+			return "Monthly on the last day"
+		}
+		return fmt.Sprintf("Monthly on day %d", r.ByMonthDay[0])
+
+	default:
+		return r.String()
+	}
+}
+
+// Next returns the first occurrence strictly after from, keeping from's time of day.  It returns false if the rule
+// has no further occurrences.
+func (r TaskRecurrence) Next(from time.Time) (time.Time, bool) {
+	var (
+		next time.Time
+		ok   bool
+	)
+
+	switch r.Freq {
+	case TaskRecurrenceFreqDaily:
+		next, ok = r.nextDaily(from)
+	case TaskRecurrenceFreqWeekly:
+		next, ok = r.nextWeekly(from)
+	case TaskRecurrenceFreqMonthly:
+		next, ok = r.nextMonthly(from)
+	case TaskRecurrenceFreqYearly:
+		next, ok = r.nextYearly(from)
+	}
+
+	if !ok || (!r.Until.IsZero() && next.After(r.Until)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r TaskRecurrence) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+func (r TaskRecurrence) matchesFilters(tm time.Time) bool {
+	if days := r.weekdays(); len(days) > 0 && !slices.Contains(days, tm.Weekday()) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !slices.Contains(r.resolveMonthDays(tm.Year(), tm.Month()), tm.Day()) {
+		return false
+	}
+	return true
+}
+
+func (r TaskRecurrence) nextDaily(from time.Time) (time.Time, bool) {
+	for i := 1; i <= taskRecurrenceMaxIterations; i++ {
+		candidate := from.AddDate(0, 0, i*r.interval())
+		if r.matchesFilters(candidate) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r TaskRecurrence) nextWeekly(from time.Time) (time.Time, bool) {
+	days := r.weekdays()
+	if len(days) == 0 {
+		return from.AddDate(0, 0, 7*r.interval()), true
+	}
+	fromWeek := civilWeekStart(from)
+	for i := 1; i <= taskRecurrenceMaxIterations; i++ {
+		candidate := from.AddDate(0, 0, i)
+		weeks := civilDaysBetween(fromWeek, civilWeekStart(candidate)) / 7
+		if weeks%r.interval() == 0 && slices.Contains(days, candidate.Weekday()) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r TaskRecurrence) nextMonthly(from time.Time) (time.Time, bool) {
+	for k := 0; k <= taskRecurrenceMaxIterations; k += r.interval() {
+		first := time.Date(from.Year(), from.Month()+time.Month(k), 1, 0, 0, 0, 0, from.Location())
+		var days []int
+		switch {
+		case len(r.ByMonthDay) > 0:
+			days = r.resolveMonthDays(first.Year(), first.Month())
+		case len(r.ByDay) > 0:
+			days = r.resolveMonthWeekdays(first.Year(), first.Month())
+		default:
+			days = r.resolveMonthDays(first.Year(), first.Month(), from.Day())
+		}
+		slices.Sort(days)
+		for _, day := range days {
+			candidate := time.Date(
+				first.Year(), first.Month(), day,
+				from.Hour(), from.Minute(), from.Second(), from.Nanosecond(),
+				from.Location(),
+			)
+			if candidate.After(from) {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r TaskRecurrence) nextYearly(from time.Time) (time.Time, bool) {
+	for k := r.interval(); k <= taskRecurrenceMaxIterations; k += r.interval() {
+		candidate := time.Date(
+			from.Year()+k, from.Month(), from.Day(),
+			from.Hour(), from.Minute(), from.Second(), from.Nanosecond(),
+			from.Location(),
+		)
+		// skip years where the date doesn't exist, e.g. Feb 29th.
+		if candidate.Day() == from.Day() {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// resolveMonthDays turns BYMONTHDAY values (or the provided fallback) into valid days of the given month, dropping
+// days the month doesn't have.
+func (r TaskRecurrence) resolveMonthDays(year int, month time.Month, fallback ...int) []int {
+	src := r.ByMonthDay
+	if len(src) == 0 {
+		src = fallback
+	}
+	last := daysInMonth(year, month)
+	out := make([]int, 0, len(src))
+	for _, day := range src {
+		if day < 0 {
+			day = last + 1 + day
+		}
+		if day >= 1 && day <= last && !slices.Contains(out, day) {
+			out = append(out, day)
+		}
+	}
+	return out
+}
+
+func (r TaskRecurrence) resolveMonthWeekdays(year int, month time.Month) []int {
+	last := daysInMonth(year, month)
+	out := make([]int, 0)
+	for _, bd := range r.ByDay {
+		matches := make([]int, 0, 5)
+		for day := 1; day <= last; day++ {
+			if time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() == bd.Weekday {
+				matches = append(matches, day)
+			}
+		}
+		switch {
+		case bd.Ordinal == 0:
+			out = append(out, matches...)
+		case bd.Ordinal > 0 && bd.Ordinal <= len(matches):
+			out = append(out, matches[bd.Ordinal-1])
+		case bd.Ordinal < 0 && -bd.Ordinal <= len(matches):
+			out = append(out, matches[len(matches)+bd.Ordinal])
+		}
+	}
+	return out
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func civilDate(tm time.Time) time.Time {
+	return time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func civilDaysBetween(a, b time.Time) int {
+	return int(civilDate(b).Sub(civilDate(a)).Hours() / 24)
+}
+
+// civilWeekStart returns the Monday of tm's week.
+func civilWeekStart(tm time.Time) time.Time {
+	d := civilDate(tm)
+	offset := (int(d.Weekday()) + 6) % 7
+	return d.AddDate(0, 0, -offset)
+}
+
+// createNextTaskOccurrence creates the task following a completed recurring task, moving the recurrence rule over to
+// it so that re-cycling the completed task's status can never spawn a second copy.  It returns nil if the rule has
+// no further occurrences.
+func createNextTaskOccurrence(tx *gorm.DB, task *Task) (*Task, error) {
+	rule, err := ParseTaskRecurrence(task.Recurrence)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing recurrence for task %d: %w", task.ID, err)
+	}
+
+	from := task.DueDate
+	if from.IsZero() {
+		from = time.Now()
+	}
+
+	var next *Task
+
+	if rule.Count != 1 {
+		if dueDate, ok := rule.Next(from); ok {
+			if rule.Count > 1 {
+				rule.Count--
+			}
+			next = &Task{
+				Label:        task.Label,
+				Description:  task.Description,
+				Status:       TaskStatusTodo,
+				SortOrder:    GetNextTaskOrderNum(),
+				UserPriority: task.UserPriority,
+				DueDate:      dueDate,
+				Recurrence:   rule.String(),
+				TaskListID:   task.TaskListID,
+			}
+			if err = CreateTask(tx, next); err != nil {
+				return nil, fmt.Errorf("error creating next occurrence of task %d: %w", task.ID, err)
+			}
+
+			var subtasks []Subtask
+			if err = taskSubtasksModelQueryOpt(task.ID)(tx.Model(&Subtask{})).Find(&subtasks).Error; err != nil {
+				return nil, fmt.Errorf("error loading subtasks of task %d: %w", task.ID, err)
+			}
+			for i := range subtasks {
+				subtasks[i].Model = gorm.Model{}
+				subtasks[i].Done = false
+			}
+			if err = SaveSubtasks(tx, next.ID, subtasks); err != nil {
+				return nil, err
+			}
+
+			var tags []Tag
+			if err = tx.Model(task).Association("Tags").Find(&tags); err != nil {
+				return nil, fmt.Errorf("error loading tags of task %d: %w", task.ID, err)
+			}
+			if len(tags) > 0 {
+				if err = tx.Model(next).Association("Tags").Replace(tags); err != nil {
+					return nil, fmt.Errorf("error copying tags to task %d: %w", next.ID, err)
+				}
+			}
+		}
+	}
+
+	task.Recurrence = ""
+	if err = tx.Model(task).Update("Recurrence", task.Recurrence).Error; err != nil {
+		return nil, fmt.Errorf("error clearing recurrence on task %d: %w", task.ID, err)
+	}
+
+	return next, nil
+}
+
+// newTaskRecurrenceEditor builds the recurrence inputs used by MutateTaskView.  The returned func produces the rule
+// to store, or an error if the inputs don't describe a valid rule.
+func newTaskRecurrenceEditor(rule string) (fyne.CanvasObject, func() (string, error)) {
+	var current *TaskRecurrence
+	if rule != "" {
+		current, _ = ParseTaskRecurrence(rule)
+	}
+
+	weekdayNames := make([]string, len(taskRecurrenceWeekdays))
+	for i, wd := range taskRecurrenceWeekdays {
+		weekdayNames[i] = wd.String()[:3]
+	}
+	weekdayCheck := widget.NewCheckGroup(weekdayNames, nil)
+	weekdayCheck.Horizontal = true
+
+	monthDayInput := widget.NewEntry()
+	monthDayInput.PlaceHolder = "Day of month (1-31, -1 for last)"
+
+	everyNInput := widget.NewEntry()
+	everyNInput.PlaceHolder = "Number of days"
+
+	customInput := widget.NewEntry()
+	customInput.PlaceHolder = "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"
+
+	chosen := TaskRecurrenceTitleNever
+	if current != nil {
+		chosen = current.Title()
+		switch chosen {
+		case TaskRecurrenceTitleWeekly:
+			selected := make([]string, 0)
+			for _, wd := range current.weekdays() {
+				selected = append(selected, wd.String()[:3])
+			}
+			weekdayCheck.SetSelected(selected)
+		case TaskRecurrenceTitleMonthly:
+			monthDayInput.SetText(strconv.Itoa(current.ByMonthDay[0]))
+		case TaskRecurrenceTitleEveryNDay:
+			everyNInput.SetText(strconv.Itoa(current.Interval))
+		}
+		customInput.SetText(current.String())
+	} else if rule != "" {
+		chosen = TaskRecurrenceTitleCustom
+		customInput.SetText(rule)
+	}
+
+	optionsContainer := container.NewStack()
+	renderOptions := func() {
+		optionsContainer.RemoveAll()
+		switch chosen {
+		case TaskRecurrenceTitleWeekly:
+			optionsContainer.Add(weekdayCheck)
+		case TaskRecurrenceTitleMonthly:
+			optionsContainer.Add(monthDayInput)
+		case TaskRecurrenceTitleEveryNDay:
+			optionsContainer.Add(everyNInput)
+		case TaskRecurrenceTitleCustom:
+			optionsContainer.Add(customInput)
+		}
+	}
+
+	recurrenceSelect := widget.NewSelect(TaskRecurrenceTitles, func(s string) {
+		chosen = s
+		renderOptions()
+	})
+	recurrenceSelect.SetSelected(chosen)
+
+	value := func() (string, error) {
+		var r TaskRecurrence
+		switch chosen {
+		case TaskRecurrenceTitleNever:
+			return "", nil
+		case TaskRecurrenceTitleDaily:
+			r = TaskRecurrence{Freq: TaskRecurrenceFreqDaily, Interval: 1}
+		case TaskRecurrenceTitleWeekdays:
+			r = TaskRecurrence{Freq: TaskRecurrenceFreqWeekly, Interval: 1}
+			for _, wd := range taskRecurrenceWorkWeek {
+				r.ByDay = append(r.ByDay, TaskRecurrenceByDay{Weekday: wd})
+			}
+		case TaskRecurrenceTitleWeekly:
+			r = TaskRecurrence{Freq: TaskRecurrenceFreqWeekly, Interval: 1}
+			for i, name := range weekdayNames {
+				if slices.Contains(weekdayCheck.Selected, name) {
+					r.ByDay = append(r.ByDay, TaskRecurrenceByDay{Weekday: taskRecurrenceWeekdays[i]})
+				}
+			}
+			if len(r.ByDay) == 0 {
+				return "", errors.New("choose at least one day of the week to repeat on")
+			}
+		case TaskRecurrenceTitleMonthly:
+			day, err := strconv.Atoi(strings.TrimSpace(monthDayInput.Text))
+			if err != nil || day == 0 || day < -31 || day > 31 {
+				return "", fmt.Errorf("invalid day of month %q", monthDayInput.Text)
+			}
+			r = TaskRecurrence{Freq: TaskRecurrenceFreqMonthly, Interval: 1, ByMonthDay: []int{day}}
+		case TaskRecurrenceTitleEveryNDay:
+			n, err := strconv.Atoi(strings.TrimSpace(everyNInput.Text))
+			if err != nil || n < 1 {
+				return "", fmt.Errorf("invalid number of days %q", everyNInput.Text)
+			}
+			r = TaskRecurrence{Freq: TaskRecurrenceFreqDaily, Interval: n}
+		case TaskRecurrenceTitleCustom:
+			parsed, err := ParseTaskRecurrence(customInput.Text)
+			if err != nil {
+				return "", err
+			}
+			r = *parsed
+		}
+		return r.String(), nil
+	}
+
+	return container.NewVBox(recurrenceSelect, optionsContainer), value
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTaskRecurrence(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{rule: "RRULE:freq=weekly;byday=mo,fr", want: "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{rule: "FREQ=DAILY;INTERVAL=1", want: "FREQ=DAILY"},
+		{rule: "FREQ=DAILY;INTERVAL=3;", want: "FREQ=DAILY;INTERVAL=3"},
+		{rule: "FREQ=WEEKLY;WKST=SU;BYDAY=MO", want: "FREQ=WEEKLY;BYDAY=MO"},
+		{rule: "FREQ=MONTHLY;BYDAY=-1FR", want: "FREQ=MONTHLY;BYDAY=-1FR"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=3", want: "FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=3"},
+		{rule: "FREQ=DAILY;UNTIL=20240102T100000Z", want: "FREQ=DAILY;UNTIL=20240102T100000Z"},
+		{rule: "FREQ=YEARLY", want: "FREQ=YEARLY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := ParseTaskRecurrence(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTaskRecurrenceInvalid(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"RRULE:",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ=DAILY;BYSETPOS=1",
+	} {
+		t.Run(rule, func(t *testing.T) {
+			if r, err := ParseTaskRecurrence(rule); err == nil {
+				t.Errorf("parsed as %q, want an error", r.String())
+			}
+		})
+	}
+}
+
+func TestTaskRecurrenceNext(t *testing.T) {
+	utc := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 10, 0, 0, 0, time.UTC)
+	}
+	local := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name string
+		rule string
+		from time.Time
+		want time.Time // zero when there is no next occurrence
+	}{
+		{name: "daily", rule: "FREQ=DAILY", from: utc(2024, 1, 1), want: utc(2024, 1, 2)},
+		{name: "every 3 days", rule: "FREQ=DAILY;INTERVAL=3", from: utc(2024, 1, 1), want: utc(2024, 1, 4)},
+		{name: "daily on weekends", rule: "FREQ=DAILY;BYDAY=SA,SU", from: utc(2024, 1, 5), want: utc(2024, 1, 6)},
+		{name: "daily on the 1st", rule: "FREQ=DAILY;BYMONTHDAY=1", from: utc(2024, 1, 15), want: utc(2024, 2, 1)},
+		{name: "weekly", rule: "FREQ=WEEKLY", from: utc(2024, 1, 1), want: utc(2024, 1, 8)},
+		{name: "weekly on days", rule: "FREQ=WEEKLY;BYDAY=MO,WE,FR", from: utc(2024, 1, 5), want: utc(2024, 1, 8)},
+		{name: "fortnightly", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", from: utc(2024, 1, 1), want: utc(2024, 1, 15)},
+		{name: "fortnightly later that week", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", from: utc(2024, 1, 1), want: utc(2024, 1, 5)},
+		{name: "monthly from Jan 31 skips Feb", rule: "FREQ=MONTHLY", from: utc(2024, 1, 31), want: utc(2024, 3, 31)},
+		{name: "monthly on the 31st", rule: "FREQ=MONTHLY;BYMONTHDAY=31", from: utc(2024, 1, 31), want: utc(2024, 3, 31)},
+		{name: "last day into a leap Feb", rule: "FREQ=MONTHLY;BYMONTHDAY=-1", from: utc(2024, 1, 31), want: utc(2024, 2, 29)},
+		{name: "last day into a common Feb", rule: "FREQ=MONTHLY;BYMONTHDAY=-1", from: utc(2023, 1, 31), want: utc(2023, 2, 28)},
+		{name: "last day after a leap day", rule: "FREQ=MONTHLY;BYMONTHDAY=-1", from: utc(2024, 2, 29), want: utc(2024, 3, 31)},
+		{name: "every other month", rule: "FREQ=MONTHLY;INTERVAL=2", from: utc(2024, 1, 15), want: utc(2024, 3, 15)},
+		{name: "first Monday", rule: "FREQ=MONTHLY;BYDAY=1MO", from: utc(2024, 1, 1), want: utc(2024, 2, 5)},
+		{name: "last Friday", rule: "FREQ=MONTHLY;BYDAY=-1FR", from: utc(2024, 1, 26), want: utc(2024, 2, 23)},
+		{name: "fifth Monday skips months without one", rule: "FREQ=MONTHLY;BYDAY=5MO", from: utc(2024, 1, 29), want: utc(2024, 4, 29)},
+		{name: "yearly", rule: "FREQ=YEARLY", from: utc(2023, 3, 1), want: utc(2024, 3, 1)},
+		{name: "yearly on a leap day", rule: "FREQ=YEARLY", from: utc(2024, 2, 29), want: utc(2028, 2, 29)},
+		{name: "until the next occurrence", rule: "FREQ=DAILY;UNTIL=20240102T100000Z", from: utc(2024, 1, 1), want: utc(2024, 1, 2)},
+		{name: "until just before it", rule: "FREQ=DAILY;UNTIL=20240102T095959Z", from: utc(2024, 1, 1)},
+		{name: "until a date includes the day", rule: "FREQ=DAILY;UNTIL=20240102", from: local(2024, 1, 1, 23), want: local(2024, 1, 2, 23)},
+		{name: "until a date excludes the next", rule: "FREQ=DAILY;UNTIL=20240102", from: local(2024, 1, 2, 9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseTaskRecurrence(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := r.Next(tt.from)
+			if tt.want.IsZero() {
+				if ok {
+					t.Errorf("got %s, want no next occurrence", got)
+				}
+				return
+			}
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("got %s (%t), want %s", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateNextTaskOccurrenceCount(t *testing.T) {
+	_, db := newTestTaskApp(t)
+	due := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	task := createTestTask(t, db, Task{Label: "Water plants", DueDate: due, Recurrence: "FREQ=DAILY;COUNT=2"})
+
+	next, err := UpdateTaskStatus(db, &task, TaskStatusDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == nil {
+		t.Fatal("no next occurrence with one remaining")
+	}
+	if !next.DueDate.Equal(due.AddDate(0, 0, 1)) {
+		t.Errorf("next due %s", next.DueDate)
+	}
+	if next.Recurrence != "FREQ=DAILY;COUNT=1" {
+		t.Errorf("next recurrence %q", next.Recurrence)
+	}
+	if task.Recurrence != "" {
+		t.Errorf("completed task kept recurrence %q", task.Recurrence)
+	}
+
+	last, err := UpdateTaskStatus(db, next, TaskStatusDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last != nil {
+		t.Errorf("created an occurrence past the count, due %s", last.DueDate)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	sqlDateFormat = "2006-01-02"
+)
+
+// dueBetweenModelQueryOpt finds tasks due on the days from from up to, but not including, to, in the order they're
+// due.
+func dueBetweenModelQueryOpt(from, to time.Time) ModelQueryOpt {
+	return func(db *gorm.DB) *gorm.DB {
+		// gorm appends each Order, so due_date comes first and id only breaks ties.
+		return WithSort("id asc")(WithSort("due_date asc")(WithPreload("TaskList")(db))).
+			Where(
+				"date(`tasks`.`due_date`, 'localtime') >= ? AND date(`tasks`.`due_date`, 'localtime') < ?",
+				from.Local().Format(sqlDateFormat),
+				to.Local().Format(sqlDateFormat),
+			)
+	}
+}
+
+func dayTasksModelQueryOpt(day time.Time) ModelQueryOpt {
+	day = StartOfDay(day)
+	return dueBetweenModelQueryOpt(day, day.AddDate(0, 0, 1))
+}
+
+// RescheduleTask changes when a task is due, offering to undo it.
+func (ta *TaskApp) RescheduleTask(task Task, due time.Time) {
+	previous := task.DueDate
+	res := ta.DB().Model(&task).Update("DueDate", due)
+	if res.Error != nil {
+		ta.ReportError(fmt.Sprintf("Error rescheduling %s", task.Label), res.Error, func() {
+			ta.RescheduleTask(task, due)
+		})
+		return
+	}
+	ta.RecordUndo(fmt.Sprintf("%s moved to %s", task.Label, FormatDate(due)), func(db *gorm.DB) error {
+		return db.Model(&Task{Model: gorm.Model{ID: task.ID}}).Update("DueDate", previous).Error
+	})
+	ta.RefreshView()
+}
+
+// overdueTasksModelQueryOpt finds tasks still to do that were due before now.
+func overdueTasksModelQueryOpt(now time.Time) ModelQueryOpt {
+	return func(db *gorm.DB) *gorm.DB {
+		return WithSort("id asc")(WithSort("due_date asc")(WithPreload("TaskList")(db))).
+			Where("status IN ?", []uint{TaskStatusTodo, TaskStatusInProgress}).
+			// tasks without a due date hold the zero time, which isn't overdue.
+			Where("date(`tasks`.`due_date`) > '0001-01-01'").
+			Where("datetime(`tasks`.`due_date`) < ?", now.UTC().Format(time.DateTime))
+	}
+}
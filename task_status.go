@@ -12,18 +12,21 @@ import (
 )
 
 const (
-	TaskStatusTodo uint = 0
-	TaskStatusDone uint = 10
-	TaskStatusSkip uint = 20
-
-	TaskStatusTitleTodo = "Todo"
-	TaskStatusTitleDone = "Done"
-	TaskStatusTitleSkip = "Skip"
+	TaskStatusTodo       uint = 0
+	TaskStatusInProgress uint = 5
+	TaskStatusDone       uint = 10
+	TaskStatusSkip       uint = 20
+
+	TaskStatusTitleTodo       = "Todo"
+	TaskStatusTitleInProgress = "In Progress"
+	TaskStatusTitleDone       = "Done"
+	TaskStatusTitleSkip       = "Skip"
 )
 
 var (
 	TaskStatusTitles = []string{
 		TaskStatusTitleTodo,
+		TaskStatusTitleInProgress,
 		TaskStatusTitleDone,
 		TaskStatusTitleSkip,
 	}
@@ -32,6 +35,10 @@ var (
 		"task_status_todo",
 		GetConstrainedImage(TaskStatusImage(TaskStatusTodo), 50),
 	)
+	TaskStatusIconResourceInProgress = EncodeImageToResource(
+		"task_status_in_progress",
+		GetConstrainedImage(TaskStatusImage(TaskStatusInProgress), 50),
+	)
 	TaskStatusIconResourceDone = EncodeImageToResource(
 		"task_status_done",
 		GetConstrainedImage(TaskStatusImage(TaskStatusDone), 50),
@@ -48,18 +55,34 @@ func TaskStatusNumber(status string) uint {
 		return TaskStatusSkip
 	case strings.ToLower(TaskStatusTitleDone):
 		return TaskStatusDone
+	case strings.ToLower(TaskStatusTitleInProgress):
+		return TaskStatusInProgress
 
 	default:
 		return TaskStatusTodo
 	}
 }
 
+// ParseTaskStatus is the strict form of TaskStatusNumber, erroring on unknown statuses.  Dashes and underscores are
+// accepted in place of spaces.
+func ParseTaskStatus(status string) (uint, error) {
+	normalized := strings.NewReplacer("-", " ", "_", " ").Replace(strings.TrimSpace(status))
+	for _, title := range TaskStatusTitles {
+		if strings.EqualFold(title, normalized) {
+			return TaskStatusNumber(title), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown task status %q, expected one of %s", status, strings.Join(TaskStatusTitles, ", "))
+}
+
 func TaskStatusTitle(status uint) string {
 	switch status {
 	case TaskStatusSkip:
 		return TaskStatusTitleSkip
 	case TaskStatusDone:
 		return TaskStatusTitleDone
+	case TaskStatusInProgress:
+		return TaskStatusTitleInProgress
 
 	default:
 		return TaskStatusTitleTodo
@@ -72,6 +95,8 @@ func TaskStatusImage(status uint) image.Image {
 		return AssetImageStatusDone
 	case TaskStatusSkip:
 		return AssetImageStatusSkip
+	case TaskStatusInProgress:
+		return AssetImageStatusInProgress
 
 	default:
 		return AssetImageStatusTodo
@@ -84,31 +109,80 @@ func TaskStatusResource(status uint) *fyne.StaticResource {
 		return TaskStatusIconResourceDone
 	case TaskStatusSkip:
 		return TaskStatusIconResourceSkip
+	case TaskStatusInProgress:
+		return TaskStatusIconResourceInProgress
 
 	default:
 		return TaskStatusIconResourceTodo
 	}
 }
 
-func newTaskStatusSwitcherButton(db *gorm.DB, task *Task) *widget.Button {
+// TaskStatusIsClosed returns true for statuses that finish a task.
+func TaskStatusIsClosed(status uint) bool {
+	return status == TaskStatusDone || status == TaskStatusSkip
+}
+
+// UpdateTaskStatus persists a status change.  When a recurring task is closed, its next occurrence is created and
+// returned.
+func UpdateTaskStatus(db *gorm.DB, task *Task, status uint) (*Task, error) {
+	var (
+		next       *Task
+		previous   = task.Status
+		recurrence = task.Recurrence
+		spawn      = !TaskStatusIsClosed(task.Status) && TaskStatusIsClosed(status) && task.Recurrence != ""
+	)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(task).Update("Status", status).Error; err != nil {
+			return fmt.Errorf("error updating task status: %w", err)
+		}
+		if !spawn {
+			return nil
+		}
+		var err error
+		next, err = createNextTaskOccurrence(tx, task)
+		return err
+	})
+	if err != nil {
+		task.Status = previous
+		task.Recurrence = recurrence
+		return nil, err
+	}
+	task.Status = status
+	return next, nil
+}
+
+func newTaskStatusSwitcherButton(app *TaskApp, task *Task) *widget.Button {
 	var statusButton *widget.Button
 	var statusIdx = slices.Index(TaskStatusTitles, TaskStatusTitle(task.Status))
-	statusButton = widget.NewButtonWithIcon(
-		"",
-		TaskStatusResource(task.Status),
-		func() {
-			statusIdx++
-			if statusIdx == len(TaskStatusTitles) {
-				statusIdx = 0
-			}
-			task.Status = TaskStatusNumber(TaskStatusTitles[statusIdx])
-			res := db.Model(&task).Update("Status", task.Status)
-			if res.Error != nil {
-				panic(fmt.Sprintf("error updating task status: %v", res.Error))
-			}
-			statusButton.SetIcon(TaskStatusResource(task.Status))
-		},
-	)
+	var cycle func()
+	cycle = func() {
+		nextIdx := (statusIdx + 1) % len(TaskStatusTitles)
+		previous, recurrence := task.Status, task.Recurrence
+		next, err := UpdateTaskStatus(app.DB(), task, TaskStatusNumber(TaskStatusTitles[nextIdx]))
+		if err != nil {
+			app.ReportError(fmt.Sprintf("Error updating the status of %s", task.Label), err, cycle)
+			return
+		}
+		statusIdx = nextIdx
+		app.RecordUndo(
+			fmt.Sprintf("%s marked %s", task.Label, TaskStatusTitle(task.Status)),
+			func(db *gorm.DB) error {
+				if next != nil {
+					if err := PurgeTask(db, next.ID); err != nil {
+						return err
+					}
+				}
+				res := db.Model(task).Select("Status", "Recurrence").Updates(&Task{Status: previous, Recurrence: recurrence})
+				if res.Error != nil {
+					return res.Error
+				}
+				task.Status, task.Recurrence = previous, recurrence
+				return nil
+			},
+		)
+		statusButton.SetIcon(TaskStatusResource(task.Status))
+	}
+	statusButton = widget.NewButtonWithIcon("", TaskStatusResource(task.Status), cycle)
 	statusButton.Importance = widget.LowImportance
 
 	return statusButton
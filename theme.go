@@ -2,6 +2,7 @@ package main
 
 import (
 	"image/color"
+	"sync/atomic"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -9,9 +10,11 @@ import (
 )
 
 var (
-	ColorRed = color.RGBA{R: 255}
+	ColorRed    = color.RGBA{R: 255}
+	ColorOrange = color.RGBA{R: 204, G: 102}
 
 	ColorBackground = color.RGBA{R: 242, G: 223, B: 121} // F2DF79
+	ColorNight      = color.RGBA{R: 28, G: 24, B: 56}    // 1C1838
 	ColorBlue       = color.RGBA{R: 11, G: 2, B: 133}    // 0B0285
 	ColorPurple     = color.RGBA{R: 139, G: 129, B: 253} // 8B81FD
 	ColorPink       = color.RGBA{R: 227, G: 204, B: 252} // E3CCFC
@@ -21,10 +24,40 @@ var (
 	IconEdit = EncodeImageToResource("edit", AssetImageEditIcon)
 )
 
+const (
+	ThemeVariantSystem = "System"
+	ThemeVariantLight  = "Light"
+	ThemeVariantDark   = "Dark"
+)
+
+var (
+	ThemeVariantOptions = []string{ThemeVariantSystem, ThemeVariantLight, ThemeVariantDark}
+
+	// lightColors and darkColors are the colors each variant overrides in the default theme.  The light variant is
+	// dark text on yellow, the dark variant yellow text on a deep purple.
+	lightColors = map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameBackground: ColorBackground,
+		theme.ColorNameError:      ColorRed,
+		theme.ColorNameWarning:    ColorOrange,
+	}
+	darkColors = map[fyne.ThemeColorName]color.Color{
+		theme.ColorNameBackground: ColorNight,
+		theme.ColorNameForeground: ColorBackground,
+		theme.ColorNamePrimary:    ColorPurple,
+		theme.ColorNameFocus:      ColorPurple,
+		theme.ColorNameSelection:  color.NRGBA{R: 139, G: 129, B: 253, A: 64},
+		theme.ColorNameError:      color.RGBA{R: 255, G: 107, B: 107},
+		theme.ColorNameWarning:    color.RGBA{R: 255, G: 170, B: 68},
+	}
+)
+
 var _ fyne.Theme = (*TodoTodayTheme)(nil)
 
 type TodoTodayTheme struct {
 	fyne.Theme
+
+	// variant overrides the variant the OS asks for, nil to follow it.
+	variant atomic.Pointer[fyne.ThemeVariant]
 }
 
 func NewTheme() *TodoTodayTheme {
@@ -34,14 +67,36 @@ func NewTheme() *TodoTodayTheme {
 	return &th
 }
 
-func (th *TodoTodayTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+// SetVariant picks the variant from one of ThemeVariantOptions, returning whether it changed.
+func (th *TodoTodayTheme) SetVariant(name string) bool {
+	var variant *fyne.ThemeVariant
 	switch name {
-	case theme.ColorNameBackground:
-		return ColorBackground
+	case ThemeVariantLight:
+		v := theme.VariantLight
+		variant = &v
+	case ThemeVariantDark:
+		v := theme.VariantDark
+		variant = &v
+	}
+	prev := th.variant.Swap(variant)
+	if prev == nil || variant == nil {
+		return prev != variant
+	}
+	return *prev != *variant
+}
 
-	default:
-		return th.Theme.Color(name, theme.VariantLight)
+func (th *TodoTodayTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if v := th.variant.Load(); v != nil {
+		variant = *v
+	}
+	colors := lightColors
+	if variant == theme.VariantDark {
+		colors = darkColors
+	}
+	if c, ok := colors[name]; ok {
+		return c
 	}
+	return th.Theme.Color(name, variant)
 }
 
 func ResizeTextToFit(txt *canvas.Text, baseSize, maxWidth float32) {
@@ -54,7 +109,7 @@ func ResizeTextToFit(txt *canvas.Text, baseSize, maxWidth float32) {
 }
 
 func HeaderCanvas(text string, opts ...func(txt *canvas.Text)) *canvas.Text {
-	txt := canvas.NewText(text, color.Black)
+	txt := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
 	txt.Alignment = fyne.TextAlignCenter
 	txt.TextSize = 32
 	txt.TextStyle = fyne.TextStyle{
@@ -67,7 +122,7 @@ func HeaderCanvas(text string, opts ...func(txt *canvas.Text)) *canvas.Text {
 }
 
 func FormLabel(text string, opts ...func(txt *canvas.Text)) *canvas.Text {
-	txt := canvas.NewText(text, color.Black)
+	txt := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
 	txt.TextStyle = fyne.TextStyle{
 		Bold: true,
 	}
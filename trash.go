@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeleteTaskList soft deletes a task list along with its tasks, stamping them all with the same deletion time so
+// RestoreTaskList can bring them back together.
+func DeleteTaskList(db *gorm.DB, taskList *TaskList) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&Task{}).Where("task_list_id = ?", taskList.ID).Update("deleted_at", now)
+		if res.Error != nil {
+			return fmt.Errorf("error deleting tasks of task list %d: %w", taskList.ID, res.Error)
+		}
+		res = tx.Model(taskList).Update("deleted_at", now)
+		if res.Error != nil {
+			return fmt.Errorf("error deleting task list %d: %w", taskList.ID, res.Error)
+		}
+		return nil
+	})
+}
+
+// RestoreTaskList un-deletes a task list along with the tasks that were deleted with it.
+func RestoreTaskList(db *gorm.DB, taskListID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var taskList TaskList
+		if err := tx.Unscoped().First(&taskList, taskListID).Error; err != nil {
+			return fmt.Errorf("error loading task list %d: %w", taskListID, err)
+		}
+		if !taskList.DeletedAt.Valid {
+			return nil
+		}
+		res := tx.Unscoped().
+			Model(&Task{}).
+			Where("task_list_id = ? AND deleted_at >= ?", taskListID, taskList.DeletedAt.Time).
+			Update("deleted_at", nil)
+		if res.Error != nil {
+			return fmt.Errorf("error restoring tasks of task list %d: %w", taskListID, res.Error)
+		}
+		res = tx.Unscoped().Model(&taskList).Update("deleted_at", nil)
+		if res.Error != nil {
+			return fmt.Errorf("error restoring task list %d: %w", taskListID, res.Error)
+		}
+		return nil
+	})
+}
+
+// RestoreTask un-deletes a task.  If the task's list is also deleted, the list is restored too so the task doesn't
+// reappear under a list that can't be seen.
+func RestoreTask(db *gorm.DB, taskID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var task Task
+		if err := tx.Unscoped().First(&task, taskID).Error; err != nil {
+			return fmt.Errorf("error loading task %d: %w", taskID, err)
+		}
+		res := tx.Unscoped().Model(&task).Update("deleted_at", nil)
+		if res.Error != nil {
+			return fmt.Errorf("error restoring task %d: %w", taskID, res.Error)
+		}
+		if !task.TaskListID.Valid {
+			return nil
+		}
+		res = tx.Unscoped().
+			Model(&TaskList{}).
+			Where("id = ? AND deleted_at IS NOT NULL", task.TaskListID.V).
+			Update("deleted_at", nil)
+		if res.Error != nil {
+			return fmt.Errorf("error restoring task list %d: %w", task.TaskListID.V, res.Error)
+		}
+		return nil
+	})
+}
+
+// PurgeTaskList permanently deletes a task list.  Its tasks, their subtasks and tag links go with it via foreign key
+// cascades.
+func PurgeTaskList(db *gorm.DB, taskListID uint) error {
+	if err := db.Unscoped().Delete(&TaskList{}, taskListID).Error; err != nil {
+		return fmt.Errorf("error purging task list %d: %w", taskListID, err)
+	}
+	return nil
+}
+
+// PurgeTask permanently deletes a task, its subtasks and tag links.
+func PurgeTask(db *gorm.DB, taskID uint) error {
+	if err := db.Unscoped().Delete(&Task{}, taskID).Error; err != nil {
+		return fmt.Errorf("error purging task %d: %w", taskID, err)
+	}
+	return nil
+}
+
+// PurgeTrash permanently deletes every task list and task that was deleted before the given time, returning the
+// number of rows removed.
+func PurgeTrash(db *gorm.DB, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().Where("deleted_at < ?", deletedBefore).Delete(&TaskList{})
+		if res.Error != nil {
+			return fmt.Errorf("error purging task lists: %w", res.Error)
+		}
+		purged += res.RowsAffected
+		res = tx.Unscoped().Where("deleted_at < ?", deletedBefore).Delete(&Task{})
+		if res.Error != nil {
+			return fmt.Errorf("error purging tasks: %w", res.Error)
+		}
+		purged += res.RowsAffected
+		return nil
+	})
+	return purged, err
+}
+
+func trashedModelQueryOpt() ModelQueryOpt {
+	return func(db *gorm.DB) *gorm.DB {
+		return WithSort("deleted_at desc")(db.Unscoped()).
+			Where("deleted_at IS NOT NULL")
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// undoStackLimit is the number of mutations remembered before the oldest are forgotten.
+	undoStackLimit = 50
+)
+
+// UndoFunc reverts a single recorded mutation.  It is always run inside a transaction.
+type UndoFunc func(db *gorm.DB) error
+
+type undoAction struct {
+	description string
+	undo        UndoFunc
+	// leave is the route undoing removes what it shows, empty when the undo leaves every view in place.
+	leave string
+}
+
+type UndoStack struct {
+	mu      sync.Mutex
+	actions []undoAction
+}
+
+func newUndoStack() *UndoStack {
+	s := UndoStack{
+		actions: make([]undoAction, 0),
+	}
+	return &s
+}
+
+func (s *UndoStack) Push(description string, undo UndoFunc) {
+	s.push(undoAction{description: description, undo: undo})
+}
+
+func (s *UndoStack) push(action undoAction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions = append(s.actions, action)
+	if l := len(s.actions); l > undoStackLimit {
+		s.actions = s.actions[l-undoStackLimit:]
+	}
+}
+
+func (s *UndoStack) Pop() (undoAction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l := len(s.actions)
+	if l == 0 {
+		return undoAction{}, false
+	}
+	action := s.actions[l-1]
+	s.actions = s.actions[:l-1]
+	return action, true
+}
+
+// Clear forgets every recorded mutation.
+func (s *UndoStack) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions = s.actions[:0]
+}
+
+func (s *UndoStack) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.actions)
+}
+
+// taskEditColumns are the task columns written by MutateTaskView, and restored when undoing an edit.
+var taskEditColumns = []string{"Label", "Description", "Status", "UserPriority", "DueDate", "Recurrence", "TaskListID"}
+
+// undoTaskUpdate returns an UndoFunc putting a task, its checklist and tags back to how they were before an edit.
+func undoTaskUpdate(before Task, subtasks []Subtask, tags []Tag) UndoFunc {
+	restoredSubtasks := make([]Subtask, len(subtasks))
+	for i := range subtasks {
+		restoredSubtasks[i] = Subtask{Label: subtasks[i].Label, Done: subtasks[i].Done}
+	}
+	tagLabels := make([]string, len(tags))
+	for i := range tags {
+		tagLabels[i] = tags[i].Label
+	}
+	return func(db *gorm.DB) error {
+		res := db.Model(&Task{Model: gorm.Model{ID: before.ID}}).Select(taskEditColumns).Updates(&before)
+		if res.Error != nil {
+			return res.Error
+		}
+		if err := SaveSubtasks(db, before.ID, restoredSubtasks); err != nil {
+			return err
+		}
+		return SetTaskTags(db, before.ID, tagLabels)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	MaxLabelLength       = 50
+	MaxDescriptionLength = 500
+)
+
+// RequiredValidator fails when the value is blank.
+func RequiredValidator(field string) fyne.StringValidator {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return fmt.Errorf("%s is required", field)
+		}
+		return nil
+	}
+}
+
+// MaxLengthValidator fails when the value is longer than max characters.
+func MaxLengthValidator(field string, max int) fyne.StringValidator {
+	return func(s string) error {
+		if n := utf8.RuneCountInString(s); n > max {
+			return fmt.Errorf("%s is %d characters too long", field, n-max)
+		}
+		return nil
+	}
+}
+
+// AllValidators combines validators, returning the first failure.
+func AllValidators(validators ...fyne.StringValidator) fyne.StringValidator {
+	return func(s string) error {
+		for _, validate := range validators {
+			if err := validate(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateTaskDueDate fails when a task would be due on a day before its list's date.
+func ValidateTaskDueDate(dueDate time.Time, taskList *TaskList) error {
+	if taskList == nil || dueDate.IsZero() || taskList.Date.IsZero() {
+		return nil
+	}
+	if StartOfDay(dueDate).Before(StartOfDay(taskList.Date)) {
+		return fmt.Errorf("due date is before %s's date, %s", taskList.Label, FormatDate(taskList.Date))
+	}
+	return nil
+}
+
+// DuplicateTaskListLabel returns the task list in taskLists with the same label, ignoring case, other than the one
+// with ID id.
+func DuplicateTaskListLabel(label string, id uint, taskLists []TaskList) *TaskList {
+	label = strings.TrimSpace(label)
+	for i := range taskLists {
+		if taskLists[i].ID != id && strings.EqualFold(strings.TrimSpace(taskLists[i].Label), label) {
+			return &taskLists[i]
+		}
+	}
+	return nil
+}
+
+// formValidator validates the fields of a form as they change, showing each field's error under it and enabling the
+// submit button only while every field is valid.  Warnings are shown the same way but don't block submitting.
+type formValidator struct {
+	submit *widget.Button
+	checks []func() error
+}
+
+func newFormValidator() *formValidator {
+	return &formValidator{}
+}
+
+// Validate re-checks every field, returning whether the form may be submitted.
+func (f *formValidator) Validate() bool {
+	var errs []error
+	for _, check := range f.checks {
+		errs = append(errs, check())
+	}
+	valid := errors.Join(errs...) == nil
+	if f.submit != nil {
+		if valid {
+			f.submit.Enable()
+		} else {
+			f.submit.Disable()
+		}
+	}
+	return valid
+}
+
+// SetSubmit sets the button enabled only while the form is valid.
+func (f *formValidator) SetSubmit(btn *widget.Button) {
+	f.submit = btn
+	f.Validate()
+}
+
+// Entry validates entry as it is edited, returning the entry with its inline error and, when maxLen is above 0, a
+// live character count.
+func (f *formValidator) Entry(entry *widget.Entry, maxLen int, validate fyne.StringValidator) fyne.CanvasObject {
+	errText := newFormMessage(theme.ColorNameError)
+
+	counter := canvas.NewText("", theme.Color(theme.ColorNameForeground))
+	counter.TextSize = 10
+	counter.Alignment = fyne.TextAlignTrailing
+	if maxLen <= 0 {
+		counter.Hide()
+	}
+
+	f.checks = append(f.checks, func() error {
+		if maxLen > 0 {
+			n := utf8.RuneCountInString(entry.Text)
+			counter.Text = fmt.Sprintf("%d/%d", n, maxLen)
+			counter.Color = theme.Color(theme.ColorNameForeground)
+			if n > maxLen {
+				counter.Color = theme.Color(theme.ColorNameError)
+			}
+			counter.Refresh()
+		}
+		err := validate(entry.Text)
+		setFormMessage(errText, err)
+		return err
+	})
+
+	onChanged := entry.OnChanged
+	entry.OnChanged = func(s string) {
+		if onChanged != nil {
+			onChanged(s)
+		}
+		f.Validate()
+	}
+
+	return container.NewVBox(entry, container.NewBorder(nil, nil, nil, counter, errText))
+}
+
+// Check adds a validation not tied to a single entry, such as one comparing fields, returning its inline error.
+// Call Validate when any of the values it depends on change.
+func (f *formValidator) Check(validate func() error) fyne.CanvasObject {
+	errText := newFormMessage(theme.ColorNameError)
+	f.checks = append(f.checks, func() error {
+		err := validate()
+		setFormMessage(errText, err)
+		return err
+	})
+	return errText
+}
+
+// Warn adds a check that is shown like an error but doesn't stop the form being submitted.
+func (f *formValidator) Warn(warn func() error) fyne.CanvasObject {
+	warnText := newFormMessage(theme.ColorNameWarning)
+	f.checks = append(f.checks, func() error {
+		setFormMessage(warnText, warn())
+		return nil
+	})
+	return warnText
+}
+
+func newFormMessage(name fyne.ThemeColorName) *canvas.Text {
+	txt := canvas.NewText("", theme.Color(name))
+	txt.TextSize = 12
+	txt.Hide()
+	return txt
+}
+
+func setFormMessage(txt *canvas.Text, err error) {
+	if err == nil {
+		txt.Text = ""
+		txt.Hide()
+	} else {
+		txt.Text = err.Error()
+		txt.Show()
+	}
+	txt.Refresh()
+}
@@ -16,6 +16,8 @@ const (
 
 type View interface {
 	Name() string
+	// Route names the view so it can be opened again later, see viewForRoute.
+	Route() string
 	Title() []fyne.CanvasObject
 	State() ViewState
 	Foreground() fyne.CanvasObject
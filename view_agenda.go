@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/sdassow/fyne-datepicker"
+)
+
+var _ View = (*AgendaView)(nil)
+
+// AgendaView lists a week of tasks by the day and hour they're due, after any that are overdue.
+type AgendaView struct {
+	*baseView
+	week time.Time
+}
+
+// NewAgendaView shows the week containing week.
+func NewAgendaView(ta *TaskApp, week time.Time) *AgendaView {
+	v := AgendaView{
+		baseView: newBaseView("Agenda", ta),
+		week:     StartOfWeek(week, ta.Settings().FirstWeekday),
+	}
+	return &v
+}
+
+func (v *AgendaView) Route() string {
+	return AgendaRoute(v.week)
+}
+
+func (v *AgendaView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Agenda")}
+}
+
+func (v *AgendaView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	if !v.foreground() {
+		v.mu.Unlock()
+		return nil
+	}
+	v.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-v.deactivated
+		cancel()
+	}()
+
+	now := time.Now()
+	weekEnd := v.week.AddDate(0, 0, 7)
+
+	overdue, err := FindModel[Task](ctx, v.app.DB(), overdueTasksModelQueryOpt(now))
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return v.app.loadFailed("Error loading overdue tasks", err)
+	}
+
+	tasks, err := FindModel[Task](ctx, v.app.DB(), dueBetweenModelQueryOpt(v.week, weekEnd))
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return v.app.loadFailed("Error loading tasks", err)
+	}
+
+	// overdue tasks are only listed at the top.
+	isOverdue := make(map[uint]bool, len(overdue))
+	for _, task := range overdue {
+		isOverdue[task.ID] = true
+	}
+	byDay := make(map[string][]*Task)
+	for i := range tasks {
+		if isOverdue[tasks[i].ID] {
+			continue
+		}
+		key := tasks[i].DueDate.Local().Format(sqlDateFormat)
+		byDay[key] = append(byDay[key], &tasks[i])
+	}
+
+	body := container.NewVBox()
+
+	if len(overdue) > 0 {
+		body.Add(FormLabel(fmt.Sprintf("Overdue (%d)", len(overdue)), func(txt *canvas.Text) {
+			txt.Color = theme.Color(theme.ColorNameError)
+		}))
+		for i := range overdue {
+			body.Add(v.taskRow(&overdue[i], true))
+		}
+		body.Add(widget.NewSeparator())
+	}
+
+	today := StartOfDay(now)
+	for day := v.week; day.Before(weekEnd); day = day.AddDate(0, 0, 1) {
+		dayLabel := FormLabel(fmt.Sprintf("%s %s", day.Format("Monday"), FormatDate(day)))
+		if day.Equal(today) {
+			dayLabel.Color = theme.Color(theme.ColorNamePrimary)
+		}
+		body.Add(dayLabel)
+
+		dayTasks := byDay[day.Format(sqlDateFormat)]
+		if len(dayTasks) == 0 {
+			nothing := canvas.NewText("Nothing due", theme.Color(theme.ColorNameDisabled))
+			nothing.TextSize = 12
+			body.Add(nothing)
+		}
+		// tasks are in the order they're due, so each hour's are together.
+		hour := -1
+		for _, task := range dayTasks {
+			if due := task.DueDate.Local(); due.Hour() != hour {
+				hour = due.Hour()
+				hourText := canvas.NewText(FormatHour(due), theme.Color(theme.ColorNameForeground))
+				hourText.TextSize = 11
+				body.Add(container.NewBorder(nil, nil, hourText, nil, widget.NewSeparator()))
+			}
+			body.Add(v.taskRow(task, false))
+		}
+	}
+
+	weekLabel := FormLabel(fmt.Sprintf("%s - %s", FormatDate(v.week), FormatDate(weekEnd.AddDate(0, 0, -1))))
+	weekLabel.Alignment = fyne.TextAlignCenter
+
+	hdr := container.NewBorder(
+		nil,
+		nil,
+		widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
+			v.showWeek(v.week.AddDate(0, 0, -7))
+		}),
+		container.NewHBox(
+			widget.NewButtonWithIcon("", theme.CalendarIcon(), v.pickWeek),
+			widget.NewButton("Today", func() {
+				v.showWeek(time.Now())
+			}),
+			widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() {
+				v.showWeek(v.week.AddDate(0, 0, 7))
+			}),
+		),
+		weekLabel,
+	)
+
+	return container.NewBorder(hdr, nil, nil, nil, container.NewVScroll(body))
+}
+
+// taskRow is a task in the agenda, tapped to open it, with a menu to move it to another day.
+func (v *AgendaView) taskRow(task *Task, overdue bool) fyne.CanvasObject {
+	due := task.DueDate.Local()
+	dueText := canvas.NewText(FormatTime(due), theme.Color(theme.ColorNameForeground))
+	if overdue {
+		dueText.Text = FormatDateTime(due)
+		dueText.Color = theme.Color(theme.ColorNameError)
+	}
+	dueText.TextSize = 10
+
+	labelText := canvas.NewText(task.Label, theme.Color(theme.ColorNameForeground))
+	ResizeTextToFit(labelText, 14, 200)
+
+	left := container.NewHBox(newTaskStatusSwitcherButton(v.app, task))
+	if chip := ListColorChip(task.TaskList); chip != nil {
+		left.Add(chip)
+	}
+
+	var menuBtn *widget.Button
+	menuBtn = widget.NewButtonWithIcon("", theme.MoreVerticalIcon(), func() {
+		widget.ShowPopUpMenuAtRelativePosition(v.moveMenu(*task), v.app.window.Canvas(), fyne.NewPos(0, menuBtn.Size().Height), menuBtn)
+	})
+	menuBtn.Importance = widget.LowImportance
+
+	return container.NewStack(
+		widget.NewButton("", func() {
+			v.app.RenderTaskView(*task)
+		}),
+		container.NewBorder(nil, nil, left, menuBtn, container.NewVBox(labelText, dueText)),
+	)
+}
+
+// moveMenu offers to move a task to tomorrow or the start of next week, keeping the time it's due.
+func (v *AgendaView) moveMenu(task Task) *fyne.Menu {
+	today := StartOfDay(time.Now())
+	nextWeek := StartOfWeek(today, v.app.Settings().FirstWeekday).AddDate(0, 0, 7)
+	return fyne.NewMenu(
+		"",
+		fyne.NewMenuItem("Move to tomorrow", func() {
+			v.app.RescheduleTask(task, OnDay(task.DueDate, today.AddDate(0, 0, 1)))
+		}),
+		fyne.NewMenuItem("Move to next week", func() {
+			v.app.RescheduleTask(task, OnDay(task.DueDate, nextWeek))
+		}),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Edit", func() {
+			v.app.RenderMutateTaskView(&task, task.TaskList)
+		}),
+	)
+}
+
+func (v *AgendaView) pickWeek() {
+	picker := datepicker.NewDatePicker(v.week, v.app.Settings().FirstWeekday, func(t time.Time, ok bool) {
+		if ok {
+			v.showWeek(t)
+		}
+	})
+	dialog.ShowCustomConfirm("Pick a week", "Show", "Cancel", picker, func(ok bool) {
+		if ok {
+			picker.OnActioned(true)
+		}
+	}, v.app.window)
+}
+
+func (v *AgendaView) showWeek(week time.Time) {
+	v.week = StartOfWeek(week, v.app.Settings().FirstWeekday)
+	v.app.RefreshView()
+}
+
+func (v *AgendaView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+type backupEntry struct {
+	BackupInfo
+	stats BackupStats
+	err   error
+}
+
+var _ View = (*BackupsView)(nil)
+
+// BackupsView lists the database backups and restores them.
+type BackupsView struct {
+	*baseView
+}
+
+func NewBackupsView(ta *TaskApp) *BackupsView {
+	v := BackupsView{
+		baseView: newBaseView("Backups", ta),
+	}
+	return &v
+}
+
+func (v *BackupsView) Route() string {
+	return RouteBackups
+}
+
+func (v *BackupsView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Backups")}
+}
+
+func (v *BackupsView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.foreground() {
+		return nil
+	}
+
+	cfg := v.app.backups
+	if !cfg.Enabled() {
+		return container.NewVBox(widget.NewLabel("Backups are disabled, start the app with -backup-keep above 0 to enable them."))
+	}
+
+	backups, err := cfg.List()
+	if err != nil {
+		return v.app.loadFailed("Error listing backups", err)
+	}
+
+	entries := make([]backupEntry, len(backups))
+	for i, b := range backups {
+		entries[i].BackupInfo = b
+		entries[i].stats, entries[i].err = InspectBackup(b.Path)
+	}
+
+	listView := widget.NewList(
+		func() int {
+			return len(entries)
+		},
+		func() fyne.CanvasObject {
+			return container.NewStack(widget.NewLabel("Loading..."))
+		},
+		func(id widget.ListItemID, object fyne.CanvasObject) {
+			entry := entries[id]
+
+			content := object.(*fyne.Container)
+
+			content.RemoveAll()
+
+			labelText := canvas.NewText(FormatDateTime(entry.Time), theme.Color(theme.ColorNameForeground))
+			labelText.TextSize = 14
+			detail := fmt.Sprintf("%d lists, %d tasks, %s", entry.stats.TaskLists, entry.stats.Tasks, formatByteSize(entry.Size))
+			if entry.err != nil {
+				detail = fmt.Sprintf("Unreadable: %v", entry.err)
+			}
+			detailText := canvas.NewText(detail, theme.Color(theme.ColorNameForeground))
+			detailText.TextSize = 10
+
+			restoreBtn := widget.NewButtonWithIcon("", theme.HistoryIcon(), func() {
+				v.confirmRestore(entry)
+			})
+			if entry.err != nil {
+				restoreBtn.Disable()
+			}
+
+			content.Add(container.NewBorder(
+				nil,
+				nil,
+				widget.NewIcon(theme.StorageIcon()),
+				restoreBtn,
+				container.NewVBox(labelText, detailText),
+			))
+		},
+	)
+
+	backupBtn := widget.NewButtonWithIcon("Back up now", theme.DocumentSaveIcon(), func() {
+		if _, err := cfg.Backup(context.Background(), v.app.DB()); err != nil {
+			dialog.ShowError(err, v.app.window)
+			return
+		}
+		v.app.RefreshView()
+	})
+
+	hdr := container.NewVBox(
+		FormLabel(fmt.Sprintf("Keeping the newest %d in %s", cfg.Keep, cfg.Dir)),
+		backupBtn,
+	)
+
+	if len(entries) == 0 {
+		return container.NewBorder(hdr, nil, nil, nil, widget.NewLabel("No backups yet"))
+	}
+	return container.NewBorder(hdr, nil, nil, nil, listView)
+}
+
+func (v *BackupsView) confirmRestore(entry backupEntry) {
+	msg := fmt.Sprintf(
+		"Replace all lists and tasks with the backup from %s?\nThe current data is backed up first.",
+		FormatDateTime(entry.Time),
+	)
+	dialog.ShowConfirm("Restore backup", msg, func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := v.app.RestoreBackup(entry.Path); err != nil {
+			dialog.ShowError(err, v.app.window)
+			v.app.RefreshView()
+			return
+		}
+		dialog.ShowInformation("Backup restored", fmt.Sprintf("Restored %s", filepath.Base(entry.Path)), v.app.window)
+		v.app.RenderHomeView()
+	}, v.app.window)
+}
+
+func (v *BackupsView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
+
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	// calendarMaxIcons is the number of tasks a day shows before the rest are summarised as "+n".
+	calendarMaxIcons = 5
+	calendarIconSize = 14
+)
+
+var _ View = (*CalendarView)(nil)
+
+// CalendarView shows a month of tasks on the days they're due.  Tasks can be dragged to another day to reschedule
+// them.
+type CalendarView struct {
+	*baseView
+	month time.Time
+
+	cells      []*calendarDayCell
+	dropTarget *calendarDayCell
+}
+
+func NewCalendarView(ta *TaskApp, month time.Time) *CalendarView {
+	v := CalendarView{
+		baseView: newBaseView("Calendar", ta),
+		month:    StartOfMonth(month),
+	}
+	return &v
+}
+
+func (v *CalendarView) Route() string {
+	return CalendarRoute(v.month)
+}
+
+func (v *CalendarView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Calendar")}
+}
+
+func (v *CalendarView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	if !v.foreground() {
+		v.mu.Unlock()
+		return nil
+	}
+	v.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-v.deactivated
+		cancel()
+	}()
+
+	firstWeekday := v.app.Settings().FirstWeekday
+	gridStart := StartOfWeek(v.month, firstWeekday)
+	gridEnd := StartOfWeek(v.month.AddDate(0, 1, -1), firstWeekday).AddDate(0, 0, 7)
+
+	tasks, err := FindModel[Task](ctx, v.app.DB(), dueBetweenModelQueryOpt(gridStart, gridEnd))
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return v.app.loadFailed("Error loading tasks", err)
+	}
+
+	byDay := make(map[string][]Task)
+	for _, task := range tasks {
+		key := task.DueDate.Local().Format(sqlDateFormat)
+		byDay[key] = append(byDay[key], task)
+	}
+
+	today := StartOfDay(time.Now())
+	v.cells = v.cells[:0]
+	v.dropTarget = nil
+	grid := container.NewGridWithColumns(7)
+	for day := gridStart; day.Before(gridEnd); day = day.AddDate(0, 0, 1) {
+		cell := newCalendarDayCell(v, day, byDay[day.Format(sqlDateFormat)])
+		cell.inMonth = day.Month() == v.month.Month()
+		cell.today = day.Equal(today)
+		v.cells = append(v.cells, cell)
+		grid.Add(cell)
+	}
+
+	weekdays := container.NewGridWithColumns(7)
+	for i := range 7 {
+		name := canvas.NewText(time.Weekday((int(firstWeekday) + i) % 7).String()[:3], theme.Color(theme.ColorNameForeground))
+		name.Alignment = fyne.TextAlignCenter
+		name.TextSize = 12
+		weekdays.Add(name)
+	}
+
+	monthLabel := FormLabel(v.month.Format("January 2006"))
+	monthLabel.Alignment = fyne.TextAlignCenter
+
+	hdr := container.NewVBox(
+		container.NewBorder(
+			nil,
+			nil,
+			widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
+				v.showMonth(v.month.AddDate(0, -1, 0))
+			}),
+			container.NewHBox(
+				widget.NewButton("Today", func() {
+					v.showMonth(time.Now())
+				}),
+				widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() {
+					v.showMonth(v.month.AddDate(0, 1, 0))
+				}),
+			),
+			monthLabel,
+		),
+		weekdays,
+	)
+
+	ftr := container.NewHBox(
+		canvas.NewText(fmt.Sprintf("Tasks this month: %d", countInMonth(tasks, v.month)), theme.Color(theme.ColorNameForeground)),
+		layout.NewSpacer(),
+		widget.NewButtonWithIcon("New task", theme.ContentAddIcon(), func() {
+			v.app.RenderMutateTaskView(nil, nil)
+		}),
+	)
+
+	return container.NewBorder(hdr, ftr, nil, nil, grid)
+}
+
+func (v *CalendarView) showMonth(month time.Time) {
+	v.month = StartOfMonth(month)
+	v.app.RefreshView()
+}
+
+// dragOver highlights the day a task is being dragged over.
+func (v *CalendarView) dragOver(pos fyne.Position) {
+	cell := v.cellAt(pos)
+	if cell == v.dropTarget {
+		return
+	}
+	if v.dropTarget != nil {
+		v.dropTarget.setDropTarget(false)
+	}
+	v.dropTarget = cell
+	if cell != nil {
+		cell.setDropTarget(true)
+	}
+}
+
+// drop reschedules task to the day it was dragged to, keeping the time it's due.
+func (v *CalendarView) drop(task Task) {
+	cell := v.dropTarget
+	v.dropTarget = nil
+	if cell == nil {
+		return
+	}
+	cell.setDropTarget(false)
+	if StartOfDay(task.DueDate).Equal(cell.day) {
+		return
+	}
+	v.app.RescheduleTask(task, OnDay(task.DueDate, cell.day))
+}
+
+func (v *CalendarView) cellAt(pos fyne.Position) *calendarDayCell {
+	driver := fyne.CurrentApp().Driver()
+	for _, cell := range v.cells {
+		p := driver.AbsolutePositionForObject(cell)
+		size := cell.Size()
+		if pos.X >= p.X && pos.X < p.X+size.Width && pos.Y >= p.Y && pos.Y < p.Y+size.Height {
+			return cell
+		}
+	}
+	return nil
+}
+
+func (v *CalendarView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
+
+func countInMonth(tasks []Task, month time.Time) int {
+	n := 0
+	for _, task := range tasks {
+		due := task.DueDate.Local()
+		if due.Year() == month.Year() && due.Month() == month.Month() {
+			n++
+		}
+	}
+	return n
+}
+
+var (
+	_ fyne.Tappable = (*calendarDayCell)(nil)
+)
+
+// calendarDayCell is a day in the CalendarView grid, tapped to list the tasks due that day.
+type calendarDayCell struct {
+	widget.BaseWidget
+	view    *CalendarView
+	day     time.Time
+	tasks   []Task
+	inMonth bool
+	today   bool
+
+	bg *canvas.Rectangle
+}
+
+func newCalendarDayCell(view *CalendarView, day time.Time, tasks []Task) *calendarDayCell {
+	c := calendarDayCell{
+		view:  view,
+		day:   day,
+		tasks: tasks,
+	}
+	c.ExtendBaseWidget(&c)
+	return &c
+}
+
+func (c *calendarDayCell) CreateRenderer() fyne.WidgetRenderer {
+	c.bg = canvas.NewRectangle(c.background(false))
+	c.bg.StrokeColor = theme.Color(theme.ColorNameSeparator)
+	c.bg.StrokeWidth = 1
+
+	textColor := theme.Color(theme.ColorNameForeground)
+	if !c.inMonth {
+		textColor = theme.Color(theme.ColorNameDisabled)
+	}
+	dayText := canvas.NewText(strconv.Itoa(c.day.Day()), textColor)
+	dayText.TextSize = 11
+	if c.today {
+		dayText.TextStyle = fyne.TextStyle{Bold: true}
+	}
+
+	icons := container.NewGridWrap(fyne.NewSize(calendarIconSize, calendarIconSize))
+	for i, task := range c.tasks {
+		if i == calendarMaxIcons && len(c.tasks) > calendarMaxIcons+1 {
+			more := canvas.NewText(fmt.Sprintf("+%d", len(c.tasks)-i), textColor)
+			more.TextSize = 9
+			icons.Add(more)
+			break
+		}
+		icons.Add(newCalendarTaskIcon(c.view, task))
+	}
+
+	return widget.NewSimpleRenderer(container.NewStack(
+		c.bg,
+		container.NewPadded(container.NewBorder(dayText, nil, nil, nil, icons)),
+	))
+}
+
+func (c *calendarDayCell) background(dropTarget bool) color.Color {
+	switch {
+	case dropTarget:
+		return theme.Color(theme.ColorNameFocus)
+	case c.today:
+		return theme.Color(theme.ColorNameSelection)
+	default:
+		return color.Transparent
+	}
+}
+
+func (c *calendarDayCell) setDropTarget(dropTarget bool) {
+	if c.bg == nil {
+		return
+	}
+	c.bg.FillColor = c.background(dropTarget)
+	c.bg.Refresh()
+}
+
+func (c *calendarDayCell) Tapped(*fyne.PointEvent) {
+	c.view.app.RenderDayTasksView(c.day)
+}
+
+var (
+	_ fyne.Tappable  = (*calendarTaskIcon)(nil)
+	_ fyne.Draggable = (*calendarTaskIcon)(nil)
+)
+
+// calendarTaskIcon is a task's status icon in a calendarDayCell, tapped to open the task or dragged to another day.
+type calendarTaskIcon struct {
+	widget.BaseWidget
+	view *CalendarView
+	task Task
+}
+
+func newCalendarTaskIcon(view *CalendarView, task Task) *calendarTaskIcon {
+	i := calendarTaskIcon{
+		view: view,
+		task: task,
+	}
+	i.ExtendBaseWidget(&i)
+	return &i
+}
+
+func (i *calendarTaskIcon) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(widget.NewIcon(TaskStatusResource(i.task.Status)))
+}
+
+func (i *calendarTaskIcon) Tapped(*fyne.PointEvent) {
+	i.view.app.RenderTaskView(i.task)
+}
+
+func (i *calendarTaskIcon) Dragged(ev *fyne.DragEvent) {
+	i.view.dragOver(ev.AbsolutePosition)
+}
+
+func (i *calendarTaskIcon) DragEnd() {
+	i.view.drop(i.task)
+}
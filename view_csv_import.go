@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	csvPreviewRows = 5
+)
+
+var _ View = (*CSVImportView)(nil)
+
+// CSVImportView previews a CSV file and lets the user map its columns to task fields before importing it.
+type CSVImportView struct {
+	*baseView
+	raw       []byte
+	taskList  TaskList
+	hasHeader bool
+	mapping   []string
+}
+
+func NewCSVImportView(ta *TaskApp, raw []byte, taskList TaskList) *CSVImportView {
+	v := CSVImportView{
+		baseView:  newBaseView("Import CSV", ta),
+		raw:       raw,
+		taskList:  taskList,
+		hasHeader: true,
+	}
+	return &v
+}
+
+func (v *CSVImportView) Route() string {
+	// the file being imported can't be restored, so this reopens the data view it was chosen from.
+	return RouteData
+}
+
+func (v *CSVImportView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Import CSV")}
+}
+
+func (v *CSVImportView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.foreground() {
+		return nil
+	}
+
+	data, err := ReadCSV(bytes.NewReader(v.raw), v.hasHeader)
+	if err != nil {
+		return container.NewVBox(
+			widget.NewLabel(err.Error()),
+			widget.NewButton("Back", v.app.Back),
+		)
+	}
+	if len(v.mapping) != len(data.Header) {
+		v.mapping = GuessCSVMapping(data.Header)
+	}
+
+	headerCheck := widget.NewCheck("First row is a header", nil)
+	headerCheck.SetChecked(v.hasHeader)
+	headerCheck.OnChanged = func(b bool) {
+		v.hasHeader = b
+		v.mapping = nil
+		v.app.RefreshView()
+	}
+
+	mappingForm := container.NewVBox()
+	for col, name := range data.Header {
+		fieldSelect := widget.NewSelect(CSVFields, func(s string) {
+			v.mapping[col] = s
+		})
+		fieldSelect.SetSelected(v.mapping[col])
+
+		sample := widget.NewLabel(strings.Join(data.Column(col, 3), ", "))
+		sample.Truncation = fyne.TextTruncateEllipsis
+
+		mappingForm.Add(container.NewBorder(nil, nil, FormLabel(name+":"), fieldSelect, sample))
+	}
+
+	importBtn := widget.NewButtonWithIcon("Import", theme.ConfirmIcon(), func() {
+		result, err := ImportCSV(context.Background(), v.app.DB(), data, v.mapping, &v.taskList)
+		if err != nil {
+			dialog.ShowError(err, v.app.window)
+			return
+		}
+		v.showResult(result)
+	})
+
+	body := container.NewVBox(
+		FormLabel(fmt.Sprintf("Into %s, %d rows", v.taskList.Label, len(data.Rows))),
+		headerCheck,
+		FormLabel("Preview:"),
+		v.previewTable(data),
+		widget.NewSeparator(),
+		FormLabel("Columns:"),
+		mappingForm,
+	)
+
+	ftr := container.NewHBox(
+		widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), v.app.Back),
+		layout.NewSpacer(),
+		importBtn,
+	)
+
+	return container.NewBorder(nil, ftr, nil, nil, container.NewVScroll(body))
+}
+
+func (v *CSVImportView) previewTable(data *CSVData) fyne.CanvasObject {
+	rows := min(len(data.Rows), csvPreviewRows)
+
+	table := widget.NewTableWithHeaders(
+		func() (int, int) {
+			return rows, len(data.Header)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.TableCellID, object fyne.CanvasObject) {
+			var value string
+			if row := data.Rows[id.Row]; id.Col < len(row) {
+				value = row[id.Col]
+			}
+			object.(*widget.Label).SetText(value)
+		},
+	)
+	table.ShowHeaderColumn = false
+	table.UpdateHeader = func(id widget.TableCellID, object fyne.CanvasObject) {
+		if id.Row == -1 && id.Col >= 0 {
+			object.(*widget.Label).SetText(data.Header[id.Col])
+		}
+	}
+	for col := range data.Header {
+		table.SetColumnWidth(col, 120)
+	}
+
+	// give the table room for its rows, it otherwise collapses to a single cell inside a VBox.
+	return container.NewGridWrap(fyne.NewSize(360, float32(rows+1)*40), table)
+}
+
+func (v *CSVImportView) showResult(result CSVImportResult) {
+	showList := func() {
+		v.app.ReplaceWithTaskListTasksView(v.taskList)
+	}
+
+	msg := fmt.Sprintf("Imported %d tasks into %s", result.Created, v.taskList.Label)
+	if len(result.Errors) == 0 {
+		dialog.ShowInformation("Import complete", msg, v.app.window)
+		showList()
+		return
+	}
+
+	lines := make([]string, len(result.Errors))
+	for i, rowErr := range result.Errors {
+		lines[i] = rowErr.Error()
+	}
+	errLabel := widget.NewLabel(strings.Join(lines, "\n"))
+	errLabel.Wrapping = fyne.TextWrapWord
+
+	content := container.NewBorder(
+		widget.NewLabel(fmt.Sprintf("%s. %d rows were skipped:", msg, len(result.Errors))),
+		nil,
+		nil,
+		nil,
+		container.NewVScroll(errLabel),
+	)
+	d := dialog.NewCustom("Import finished with errors", "OK", content, v.app.window)
+	d.SetOnClosed(showList)
+	d.Resize(fyne.NewSize(360, 400))
+	d.Show()
+}
+
+func (v *CSVImportView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+var _ View = (*DataView)(nil)
+
+// DataView hosts the import and export tools.
+type DataView struct {
+	*baseView
+}
+
+func NewDataView(ta *TaskApp) *DataView {
+	v := DataView{
+		baseView: newBaseView("Data", ta),
+	}
+	return &v
+}
+
+func (v *DataView) Route() string {
+	return RouteData
+}
+
+func (v *DataView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Data")}
+}
+
+func (v *DataView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.foreground() {
+		return nil
+	}
+
+	taskLists, err := FindModel[TaskList](context.Background(), v.app.DB(), WithSort("date desc"))
+	if err != nil {
+		return v.app.loadFailed("Error loading task lists", err)
+	}
+
+	return container.NewVScroll(
+		container.NewVBox(
+			v.jsonSection(),
+			v.icalSection(taskLists),
+			v.todoTxtSection(taskLists),
+			v.markdownSection(taskLists),
+			v.csvSection(taskLists),
+		),
+	)
+}
+
+func (v *DataView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
+
+// saveFile asks the user where to save a file and hands the chosen destination to write.
+func (v *DataView) saveFile(fileName string, write func(w io.Writer) error) {
+	d := dialog.NewFileSave(func(wc fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.app.window)
+			return
+		}
+		if wc == nil {
+			return
+		}
+		err = write(wc)
+		if cerr := wc.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			dialog.ShowError(err, v.app.window)
+			return
+		}
+		dialog.ShowInformation("Export complete", fmt.Sprintf("Saved %s", wc.URI().Name()), v.app.window)
+	}, v.app.window)
+	d.SetFileName(fileName)
+	d.Show()
+}
+
+// openFile asks the user for a file with one of the given extensions and hands it to read.
+func (v *DataView) openFile(extensions []string, read func(r io.Reader) error) {
+	d := dialog.NewFileOpen(func(rc fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.app.window)
+			return
+		}
+		if rc == nil {
+			return
+		}
+		defer func() { _ = rc.Close() }()
+		if err = read(rc); err != nil {
+			dialog.ShowError(err, v.app.window)
+		}
+	}, v.app.window)
+	d.SetFilter(storage.NewExtensionFileFilter(extensions))
+	d.Show()
+}
+
+const (
+	exportScopeAll   = "All tasks"
+	exportScopeToday = "Today's tasks"
+)
+
+// newExportScopeSelect lets the user choose which tasks an export covers: every task, today's tasks or a single list.
+func newExportScopeSelect(taskLists []TaskList) (*widget.Select, func() []ModelQueryOpt) {
+	options := []string{exportScopeAll, exportScopeToday}
+	for _, tl := range taskLists {
+		options = append(options, tl.Label)
+	}
+	sel := widget.NewSelect(options, nil)
+	sel.SetSelectedIndex(0)
+
+	return sel, func() []ModelQueryOpt {
+		switch idx := sel.SelectedIndex(); idx {
+		case 0, -1:
+			return []ModelQueryOpt{WithSort("due_date asc"), WithSort("id asc")}
+		case 1:
+			return []ModelQueryOpt{todaysTasksModelQueryOpt()}
+		default:
+			return []ModelQueryOpt{taskListTasksModelQueryOpt(taskLists[idx-2].ID)}
+		}
+	}
+}
+
+// newTaskListSelect lets the user choose the list imported tasks are added to.
+func newTaskListSelect(taskLists []TaskList) (*widget.Select, func() *TaskList) {
+	options := make([]string, len(taskLists))
+	for i, tl := range taskLists {
+		options[i] = tl.Label
+	}
+	sel := widget.NewSelect(options, nil)
+	if len(options) > 0 {
+		sel.SetSelectedIndex(0)
+	}
+
+	return sel, func() *TaskList {
+		if idx := sel.SelectedIndex(); idx >= 0 {
+			return &taskLists[idx]
+		}
+		return nil
+	}
+}
+
+func exportFileName(ext string) string {
+	return fmt.Sprintf("it488-%s.%s", time.Now().Format("20060102-150405"), ext)
+}
+
+func (v *DataView) jsonSection() fyne.CanvasObject {
+	includeDeleted := widget.NewCheck("Include deleted", nil)
+
+	exportBtn := widget.NewButtonWithIcon("Export", theme.DocumentSaveIcon(), func() {
+		v.saveFile(exportFileName("json"), func(w io.Writer) error {
+			return ExportJSON(context.Background(), v.app.DB(), w, includeDeleted.Checked)
+		})
+	})
+
+	modeSelect := widget.NewSelect(ImportModes, nil)
+	modeSelect.SetSelected(ImportModeMerge)
+
+	importBtn := widget.NewButtonWithIcon("Import", theme.FolderOpenIcon(), func() {
+		doImport := func() {
+			v.openFile([]string{".json"}, func(r io.Reader) error {
+				result, err := ImportJSON(context.Background(), v.app.DB(), r, modeSelect.Selected)
+				if err != nil {
+					return err
+				}
+				if modeSelect.Selected == ImportModeReplace {
+					v.app.forgetData()
+				}
+				dialog.ShowInformation(
+					"Import complete",
+					fmt.Sprintf("Imported %d lists and %d tasks", result.TaskLists, result.Tasks),
+					v.app.window,
+				)
+				return nil
+			})
+		}
+		if modeSelect.Selected != ImportModeReplace {
+			doImport()
+			return
+		}
+		dialog.ShowConfirm(
+			"Replace all data?",
+			"Every existing list and task, including the trash, will be permanently removed.",
+			func(ok bool) {
+				if ok {
+					doImport()
+				}
+			},
+			v.app.window,
+		)
+	})
+
+	return widget.NewCard("JSON", "Full backup of lists and tasks", container.NewVBox(
+		container.NewBorder(nil, nil, nil, exportBtn, includeDeleted),
+		container.NewBorder(nil, nil, FormLabel("Mode:"), importBtn, modeSelect),
+	))
+}
+
+func (v *DataView) icalSection(taskLists []TaskList) fyne.CanvasObject {
+	scopeSelect, scopeOpts := newExportScopeSelect(taskLists)
+
+	exportBtn := widget.NewButtonWithIcon("Export", theme.DocumentSaveIcon(), func() {
+		v.saveFile(exportFileName("ics"), func(w io.Writer) error {
+			return ExportICal(context.Background(), v.app.DB(), w, scopeOpts()...)
+		})
+	})
+
+	listSelect, chosenList := newTaskListSelect(taskLists)
+
+	importBtn := widget.NewButtonWithIcon("Import", theme.FolderOpenIcon(), func() {
+		taskList := chosenList()
+		if taskList == nil {
+			dialog.ShowInformation("No task list", "Create a task list to import into first.", v.app.window)
+			return
+		}
+		v.openFile([]string{".ics", ".ical", ".ifb"}, func(r io.Reader) error {
+			created, err := ImportICal(context.Background(), v.app.DB(), r, taskList)
+			if err != nil {
+				return err
+			}
+			dialog.ShowInformation(
+				"Import complete",
+				fmt.Sprintf("Imported %d tasks into %s", created, taskList.Label),
+				v.app.window,
+			)
+			return nil
+		})
+	})
+
+	return widget.NewCard("iCalendar", "Tasks as to-dos for calendar apps", container.NewVBox(
+		container.NewBorder(nil, nil, FormLabel("Tasks:"), exportBtn, scopeSelect),
+		container.NewBorder(nil, nil, FormLabel("Into:"), importBtn, listSelect),
+	))
+}
+
+func (v *DataView) todoTxtSection(taskLists []TaskList) fyne.CanvasObject {
+	scopeSelect, scopeOpts := newExportScopeSelect(taskLists)
+
+	exportBtn := widget.NewButtonWithIcon("Export", theme.DocumentSaveIcon(), func() {
+		v.saveFile("todo.txt", func(w io.Writer) error {
+			return ExportTodoTxt(context.Background(), v.app.DB(), w, scopeOpts()...)
+		})
+	})
+
+	listSelect, chosenList := newTaskListSelect(taskLists)
+
+	importBtn := widget.NewButtonWithIcon("Import", theme.FolderOpenIcon(), func() {
+		v.openFile([]string{".txt"}, func(r io.Reader) error {
+			created, err := ImportTodoTxt(context.Background(), v.app.DB(), r, chosenList())
+			if err != nil {
+				return err
+			}
+			dialog.ShowInformation("Import complete", fmt.Sprintf("Imported %d tasks", created), v.app.window)
+			return nil
+		})
+	})
+
+	return widget.NewCard("todo.txt", "Tasks without a +project go to the chosen list", container.NewVBox(
+		container.NewBorder(nil, nil, FormLabel("Tasks:"), exportBtn, scopeSelect),
+		container.NewBorder(nil, nil, FormLabel("Into:"), importBtn, listSelect),
+	))
+}
+
+func (v *DataView) markdownSection(taskLists []TaskList) fyne.CanvasObject {
+	const (
+		reportByList = "Task list"
+		reportByDue  = "Due dates"
+	)
+
+	listSelect, chosenList := newTaskListSelect(taskLists)
+
+	today := StartOfDay(time.Now())
+	fromEntry := widget.NewEntry()
+	fromEntry.SetText(today.Format(time.DateOnly))
+	toEntry := widget.NewEntry()
+	toEntry.SetText(today.AddDate(0, 0, 6).Format(time.DateOnly))
+
+	listRow := container.NewBorder(nil, nil, FormLabel("List:"), nil, listSelect)
+	dueRow := container.NewGridWithColumns(2,
+		container.NewBorder(nil, nil, FormLabel("From:"), nil, fromEntry),
+		container.NewBorder(nil, nil, FormLabel("To:"), nil, toEntry),
+	)
+	dueRow.Hide()
+
+	kindRadio := widget.NewRadioGroup([]string{reportByList, reportByDue}, func(s string) {
+		if s == reportByDue {
+			listRow.Hide()
+			dueRow.Show()
+		} else {
+			dueRow.Hide()
+			listRow.Show()
+		}
+	})
+	kindRadio.Horizontal = true
+	kindRadio.Required = true
+	kindRadio.SetSelected(reportByList)
+
+	exportBtn := widget.NewButtonWithIcon("Export", theme.DocumentSaveIcon(), func() {
+		var (
+			report *MarkdownReport
+			err    error
+		)
+		if kindRadio.Selected == reportByDue {
+			var from, to time.Time
+			if from, err = ParseDateTime(fromEntry.Text); err == nil {
+				if to, err = ParseDateTime(toEntry.Text); err == nil {
+					report, err = NewDueDateMarkdownReport(context.Background(), v.app.DB(), from, to)
+				}
+			}
+		} else if taskList := chosenList(); taskList != nil {
+			report, err = NewTaskListMarkdownReport(context.Background(), v.app.DB(), *taskList)
+		} else {
+			err = fmt.Errorf("choose a task list to report on")
+		}
+		if err != nil {
+			dialog.ShowError(err, v.app.window)
+			return
+		}
+		v.saveFile(exportFileName("md"), func(w io.Writer) error {
+			_, err := report.WriteTo(w)
+			return err
+		})
+	})
+
+	return widget.NewCard("Markdown report", "Tasks grouped by status for status reports", container.NewVBox(
+		kindRadio,
+		listRow,
+		dueRow,
+		container.NewHBox(layout.NewSpacer(), exportBtn),
+	))
+}
+
+func (v *DataView) csvSection(taskLists []TaskList) fyne.CanvasObject {
+	scopeSelect, scopeOpts := newExportScopeSelect(taskLists)
+
+	exportBtn := widget.NewButtonWithIcon("Export", theme.DocumentSaveIcon(), func() {
+		v.saveFile(exportFileName("csv"), func(w io.Writer) error {
+			return ExportCSV(context.Background(), v.app.DB(), w, scopeOpts()...)
+		})
+	})
+
+	listSelect, chosenList := newTaskListSelect(taskLists)
+
+	importBtn := widget.NewButtonWithIcon("Import", theme.FolderOpenIcon(), func() {
+		taskList := chosenList()
+		if taskList == nil {
+			dialog.ShowInformation("No task list", "Create a task list to import into first.", v.app.window)
+			return
+		}
+		v.openFile([]string{".csv"}, func(r io.Reader) error {
+			raw, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			v.app.RenderCSVImportView(raw, *taskList)
+			return nil
+		})
+	})
+
+	return widget.NewCard("CSV", "Spreadsheets, with columns mapped on import", container.NewVBox(
+		container.NewBorder(nil, nil, FormLabel("Tasks:"), exportBtn, scopeSelect),
+		container.NewBorder(nil, nil, FormLabel("Into:"), importBtn, listSelect),
+	))
+}
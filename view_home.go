@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -28,6 +27,10 @@ func NewHomeView(app *TaskApp) *HomeView {
 	return &v
 }
 
+func (v *HomeView) Route() string {
+	return RouteHome
+}
+
 func (v *HomeView) Title() []fyne.CanvasObject {
 	return nil
 }
@@ -48,14 +51,14 @@ func (v *HomeView) Foreground() fyne.CanvasObject {
 		}()
 		latestList, err := FindOneModel[TaskList](ctx, v.app.DB(), WithSort("Date desc"))
 		if err != nil {
-			log.Error("Error finding latest task list", "err", err)
-			panic(fmt.Sprintf("Error finding latest task list: %v", err))
+			v.app.ReportError("Error finding latest task list", err, nil)
+			return
 		}
 		if latestList == nil {
 			v.app.RenderMutateTaskListView(nil)
 			return
 		}
-		v.app.RenderListOfTasksView("Today's List", nil, todaysTasksModelQueryOpt())
+		v.app.RenderTodaysTasksView()
 	})
 	todayBtn.Importance = widget.MediumImportance
 
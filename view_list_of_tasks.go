@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"image/color"
+	"slices"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -14,7 +15,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-func buildListOfTasksList(app *TaskApp, taskList *TaskList, tasks []Task, onDelete func()) fyne.CanvasObject {
+func buildListOfTasksList(app *TaskApp, taskList *TaskList, tasks []Task) fyne.CanvasObject {
 	list := widget.NewList(
 		func() int {
 			return len(tasks)
@@ -29,32 +30,50 @@ func buildListOfTasksList(app *TaskApp, taskList *TaskList, tasks []Task, onDele
 
 			content.RemoveAll()
 
-			labelText := canvas.NewText(task.Label, color.Black)
+			labelText := canvas.NewText(task.Label, theme.Color(theme.ColorNameForeground))
 			ResizeTextToFit(labelText, 14, 275)
+			text := container.NewVBox(labelText)
+			if !task.DueDate.IsZero() {
+				dueText := canvas.NewText(FormatDateTime(task.DueDate), theme.Color(theme.ColorNameForeground))
+				dueText.TextSize = 10
+				text.Add(dueText)
+			}
+
+			actions := container.NewHBox()
+			if done, total := task.SubtaskProgress(); total > 0 {
+				actions.Add(container.NewCenter(canvas.NewText(fmt.Sprintf("%d/%d", done, total), theme.Color(theme.ColorNameForeground))))
+			}
+			actions.Add(
+				widget.NewButtonWithIcon("", IconEdit, func() {
+					if taskList != nil {
+						app.RenderMutateTaskView(task, taskList)
+					} else {
+						app.RenderMutateTaskView(task, task.TaskList)
+					}
+				}),
+			)
+
+			buttons := container.NewHBox(
+				newTaskStatusSwitcherButton(app, task),
+				newTaskPrioritySwitcherButton(app, task),
+			)
+			// only tasks from across lists say which they're in.
+			if chip := ListColorChip(task.TaskList); taskList == nil && chip != nil {
+				buttons.Add(chip)
+			}
 
 			content.Add(container.NewBorder(
 				nil,
 				nil,
-				container.NewHBox(
-					newTaskStatusSwitcherButton(app.DB(), task),
-					newTaskPrioritySwitcherButton(app.DB(), task),
-				),
-				container.NewHBox(
-					widget.NewButtonWithIcon("", IconEdit, func() {
-						if taskList != nil {
-							app.RenderMutateTaskView(task, taskList, onDelete)
-						} else {
-							app.RenderMutateTaskView(task, task.TaskList, onDelete)
-						}
-					}),
-				),
-				labelText,
+				buttons,
+				actions,
+				text,
 			))
 		},
 	)
 
 	list.OnSelected = func(id widget.ListItemID) {
-		app.RenderTaskView(tasks[id], onDelete)
+		app.RenderTaskView(tasks[id])
 	}
 
 	return list
@@ -64,14 +83,16 @@ var _ View = (*ListOfTasksView)(nil)
 
 type ListOfTasksView struct {
 	*baseView
+	route    string
 	title    string
 	taskList *TaskList
 	opts     []ModelQueryOpt
 }
 
-func NewListOfTasksView(app *TaskApp, title string, taskList *TaskList, opts ...ModelQueryOpt) *ListOfTasksView {
+func NewListOfTasksView(app *TaskApp, route, title string, taskList *TaskList, opts ...ModelQueryOpt) *ListOfTasksView {
 	v := ListOfTasksView{
 		baseView: newBaseView("Task List View", app),
+		route:    route,
 		title:    title,
 		taskList: taskList,
 		opts:     opts,
@@ -79,9 +100,37 @@ func NewListOfTasksView(app *TaskApp, title string, taskList *TaskList, opts ...
 	return &v
 }
 
+func newTaskListTasksView(app *TaskApp, taskList TaskList) *ListOfTasksView {
+	return NewListOfTasksView(app, TaskListTasksRoute(taskList.ID), taskList.Label, &taskList, taskListTasksModelQueryOpt(taskList.ID))
+}
+
+func newTodaysTasksView(app *TaskApp) *ListOfTasksView {
+	return NewListOfTasksView(app, RouteToday, "Today's Tasks", nil, todaysTasksModelQueryOpt())
+}
+
+func newStatusTasksView(app *TaskApp, name string) *ListOfTasksView {
+	status := statusRoutes[name]
+	return NewListOfTasksView(app, StatusRoute(name), status.title, nil, statusTasksModelQueryOpt(status.statuses...))
+}
+
+func newTagTasksView(app *TaskApp, tag Tag) *ListOfTasksView {
+	return NewListOfTasksView(app, TagRoute(tag.ID), "#"+tag.Label, nil, tagTasksModelQueryOpt(tag.ID))
+}
+
+func newDayTasksView(app *TaskApp, day time.Time) *ListOfTasksView {
+	return NewListOfTasksView(app, DayRoute(day), FormatDate(day), nil, dayTasksModelQueryOpt(day))
+}
+
+func (v *ListOfTasksView) Route() string {
+	return v.route
+}
+
 func (v *ListOfTasksView) Title() []fyne.CanvasObject {
 	title := HeaderCanvas(v.title)
 	ResizeTextToFit(title, 32, 350)
+	if v.taskList != nil {
+		return []fyne.CanvasObject{ListColorTint(*v.taskList, title)}
+	}
 	return []fyne.CanvasObject{title}
 }
 
@@ -106,11 +155,11 @@ func (v *ListOfTasksView) Foreground() fyne.CanvasObject {
 		if errors.Is(err, context.Canceled) {
 			return nil
 		}
-		panic(fmt.Sprintf("Error counting tasks: %v", err))
+		return v.app.loadFailed("Error counting tasks", err)
 	}
 
 	ftr := container.NewHBox(
-		canvas.NewText(fmt.Sprintf("Total tasks: %d", taskCount), color.Black),
+		canvas.NewText(fmt.Sprintf("Total tasks: %d", taskCount), theme.Color(theme.ColorNameForeground)),
 		layout.NewSpacer(),
 	)
 	if v.taskList != nil {
@@ -119,17 +168,15 @@ func (v *ListOfTasksView) Foreground() fyne.CanvasObject {
 		}))
 	}
 	ftr.Add(widget.NewButtonWithIcon("New task", theme.ContentAddIcon(), func() {
-		v.app.RenderMutateTaskView(nil, v.taskList, func() {
-			v.app.RenderListOfTasksView(v.Name(), v.taskList, v.opts...)
-		})
+		v.app.RenderMutateTaskView(nil, v.taskList)
 	}))
 
-	tasks, err := FindModel[Task](ctx, v.app.DB(), v.opts...)
+	tasks, err := FindModel[Task](ctx, v.app.DB(), append(slices.Clone(v.opts), WithPreload("Subtasks"))...)
 	if err != nil {
 		if errors.Is(err, context.Canceled) {
 			return nil
 		}
-		panic(fmt.Sprintf("Error finding tasks: %v", err))
+		return v.app.loadFailed("Error loading tasks", err)
 	}
 
 	return container.NewBorder(
@@ -137,12 +184,7 @@ func (v *ListOfTasksView) Foreground() fyne.CanvasObject {
 		ftr,
 		nil,
 		nil,
-		buildListOfTasksList(
-			v.app,
-			v.taskList,
-			tasks,
-			func() { v.app.RenderListOfTasksView(v.Name(), v.taskList, append(v.opts, WithPreload("TaskList"))...) },
-		),
+		buildListOfTasksList(v.app, v.taskList, tasks),
 	)
 }
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"slices"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
@@ -25,19 +27,28 @@ type MutateTaskView struct {
 	*baseView
 	task     *Task
 	taskList *TaskList
-	onDelete func()
 }
 
-func NewMutateTaskView(app *TaskApp, task *Task, taskList *TaskList, onDelete func()) *MutateTaskView {
+func NewMutateTaskView(app *TaskApp, task *Task, taskList *TaskList) *MutateTaskView {
 	v := MutateTaskView{
 		baseView: newBaseView("Mutate Task Modal", app),
 		task:     task,
 		taskList: taskList,
-		onDelete: onDelete,
 	}
 	return &v
 }
 
+func (v *MutateTaskView) Route() string {
+	switch {
+	case v.task != nil:
+		return EditTaskRoute(v.task.ID)
+	case v.taskList != nil:
+		return NewTaskInListRoute(v.taskList.ID)
+	default:
+		return RouteNewTask
+	}
+}
+
 func (v *MutateTaskView) Title() []fyne.CanvasObject {
 	var title *canvas.Text
 	if v.task == nil {
@@ -66,7 +77,7 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 
 	allTaskLists, err := FindModel[TaskList](ctx, v.app.DB())
 	if err != nil {
-		panic(fmt.Sprintf("Error fetching task lists: %v", err))
+		return v.app.loadFailed("Error loading task lists", err)
 	}
 
 	listNames := make([]string, 0)
@@ -74,21 +85,28 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 		listNames = append(listNames, tl.Label)
 	}
 
+	settings := v.app.Settings()
+	form := newFormValidator()
+
 	titleLabel := FormLabel("Title")
 	titleInput := widget.NewEntry()
 	if v.task != nil {
 		titleInput.SetText(v.task.Label)
 	}
-	titleInput.OnChanged = func(s string) {
-		if len(s) > 50 {
-			titleInput.SetText(s[:50])
-		}
-	}
 	titleInput.PlaceHolder = "Task Title"
+	titleField := form.Entry(
+		titleInput,
+		MaxLabelLength,
+		AllValidators(RequiredValidator("Title"), MaxLengthValidator("Title", MaxLabelLength)),
+	)
 
 	chosenTaskList := v.taskList
 	if chosenTaskList == nil && v.task != nil {
-		chosenTaskList = GetListForTask(ctx, v.app.DB(), *v.task)
+		if chosenTaskList, err = GetListForTask(ctx, v.app.DB(), *v.task); err != nil {
+			return v.app.loadFailed("Error loading the task's list", err)
+		}
+	} else if chosenTaskList == nil {
+		chosenTaskList = v.app.defaultTaskList(allTaskLists)
 	}
 
 	tlSelectLabel := FormLabel("Task List")
@@ -99,6 +117,7 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 		} else {
 			chosenTaskList = &allTaskLists[idx]
 		}
+		form.Validate()
 	})
 
 	if chosenTaskList != nil {
@@ -116,7 +135,7 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 	statusSelect.SetSelected(TaskStatusTitle(chosenStatus))
 
 	var priorityContainer *fyne.Container
-	chosenPriority := TaskPriorityHigh
+	chosenPriority := strings.ToLower(settings.DefaultPriority)
 	if v.task != nil {
 		chosenPriority = TaskPriorityName(v.task.UserPriority)
 	}
@@ -144,16 +163,17 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 		prioritySelect,
 	)
 
-	chosenDueDate := time.Now()
+	chosenDueDate := settings.DueDate(time.Now())
 	if v.task != nil && !v.task.DueDate.IsZero() {
 		chosenDueDate = v.task.DueDate
 	}
 	dtpLabel := FormLabel("Due Date:")
 	dueDateDisplay := widget.NewLabel(FormatDateTime(chosenDueDate))
 	var datePickerModal *widget.PopUp
-	dtp := datepicker.NewDateTimePicker(chosenDueDate, time.Sunday, func(t time.Time, b bool) {
+	dtp := datepicker.NewDateTimePicker(chosenDueDate, settings.FirstWeekday, func(t time.Time, b bool) {
 		chosenDueDate = t
 		dueDateDisplay.SetText(FormatDateTime(chosenDueDate))
+		form.Validate()
 	})
 	dtpSaveBtn := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), func() {
 		dtp.OnActioned(true)
@@ -184,7 +204,43 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 	)
 
 	dtpButton := widget.NewButtonWithIcon("", theme.CalendarIcon(), datePickerModal.Show)
-	dueDateContainer := container.NewBorder(nil, nil, dueDateDisplay, dtpButton)
+	dueDateContainer := container.NewVBox(
+		container.NewBorder(nil, nil, dueDateDisplay, dtpButton),
+		form.Check(func() error {
+			return ValidateTaskDueDate(chosenDueDate, chosenTaskList)
+		}),
+	)
+
+	recurrenceLabel := FormLabel("Repeat:")
+	var currentRecurrence string
+	if v.task != nil {
+		currentRecurrence = v.task.Recurrence
+	}
+	recurrenceEditor, recurrenceValue := newTaskRecurrenceEditor(currentRecurrence)
+
+	var currentSubtasks []Subtask
+	if v.task != nil {
+		currentSubtasks, err = FindModel[Subtask](ctx, v.app.DB(), taskSubtasksModelQueryOpt(v.task.ID))
+		if err != nil {
+			return v.app.loadFailed("Error loading the task's checklist", err)
+		}
+	}
+	subtasksLabel := FormLabel("Checklist:")
+	subtasksEditor, subtasksValue := newSubtaskEditor(currentSubtasks)
+
+	allTags, err := FindModel[Tag](ctx, v.app.DB(), WithSort("label asc"))
+	if err != nil {
+		return v.app.loadFailed("Error loading tags", err)
+	}
+	var currentTags []Tag
+	if v.task != nil {
+		currentTags, err = FindAssociation[Task, Tag](ctx, v.app.DB(), *v.task, "Tags")
+		if err != nil {
+			return v.app.loadFailed("Error loading the task's tags", err)
+		}
+	}
+	tagsLabel := FormLabel("Tags:")
+	tagPicker, tagsValue := newTagPicker(allTags, currentTags)
 
 	descLabel := FormLabel("Description:")
 	descInput := widget.NewMultiLineEntry()
@@ -192,17 +248,13 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 		descInput.SetText(v.task.Description)
 	}
 	descInput.PlaceHolder = "Task description in Markdown"
-	descInput.OnChanged = func(s string) {
-		if len(s) > 500 {
-			descInput.SetText(s[:500])
-		}
-	}
 	descInput.SetMinRowsVisible(10)
+	descField := form.Entry(descInput, MaxDescriptionLength, MaxLengthValidator("Description", MaxDescriptionLength))
 
 	body := container.NewVScroll(
 		container.NewVBox(
 			titleLabel,
-			titleInput,
+			titleField,
 
 			tlSelectLabel,
 			tlSelect,
@@ -216,52 +268,101 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 			dtpLabel,
 			dueDateContainer,
 
+			recurrenceLabel,
+			recurrenceEditor,
+
+			subtasksLabel,
+			subtasksEditor,
+
+			tagsLabel,
+			tagPicker,
+
 			descLabel,
-			descInput,
+			descField,
 		),
 	)
 
 	ftr := container.NewHBox(layout.NewSpacer())
 
 	if v.task != nil {
-		ftr.Add(widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
-			res := v.app.DB().Delete(v.task)
-			if res.Error != nil {
-				panic(fmt.Sprintf("Error deleting task %d: %v", v.task.ID, res.Error))
-			}
-			v.onDelete()
-		}))
+		ftr.Add(widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), v.delete))
 	}
 
-	ftr.Add(widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), v.app.RenderPreviousView))
-	ftr.Add(widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), func() {
-		var res *gorm.DB
+	ftr.Add(widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), v.app.Back))
+	// save runs in one transaction so that retrying after a failure can't leave a task saved twice or half-saved.
+	var save func()
+	save = func() {
+		if !form.Validate() {
+			return
+		}
+		recurrence, err := recurrenceValue()
+		if err != nil {
+			dialog.ShowError(err, v.app.window)
+			return
+		}
+
+		// edit a copy, so v.task still holds what to undo back to if saving fails and is retried.
+		var task Task
 		if v.task != nil {
-			v.task.Label = titleInput.Text
-			v.task.Description = descInput.Text
-			v.task.Status = chosenStatus
-			v.task.UserPriority = TaskPriorityNumber(chosenPriority)
-			v.task.TaskList = chosenTaskList
-			v.task.DueDate = chosenDueDate
-			res = v.app.DB().Updates(v.task)
+			task = *v.task
 		} else {
-			task := Task{
-				Label:        titleInput.Text,
-				Description:  descInput.Text,
-				Status:       chosenStatus,
-				UserPriority: TaskPriorityNumber(chosenPriority),
-				TaskList:     chosenTaskList,
-				DueDate:      chosenDueDate,
-				Priority:     GetNextTaskOrderNum(),
+			task.SortOrder = GetNextTaskOrderNum()
+		}
+		task.Label = strings.TrimSpace(titleInput.Text)
+		task.Description = descInput.Text
+		task.Status = chosenStatus
+		task.UserPriority = TaskPriorityNumber(chosenPriority)
+		task.TaskList = chosenTaskList
+		task.TaskListID = sql.Null[int]{}
+		if chosenTaskList != nil {
+			task.TaskListID = sql.Null[int]{V: int(chosenTaskList.ID), Valid: true}
+		}
+		task.DueDate = chosenDueDate
+		task.Recurrence = recurrence
+
+		err = v.app.DB().Transaction(func(tx *gorm.DB) error {
+			if v.task != nil {
+				// Select the edited columns, as Updates would otherwise skip any cleared to their zero value.
+				if err := tx.Model(&task).Select(taskEditColumns).Updates(&task).Error; err != nil {
+					return err
+				}
+			} else if err := CreateTask(tx, &task); err != nil {
+				return err
+			}
+			if err := SaveSubtasks(tx, task.ID, subtasksValue()); err != nil {
+				return err
 			}
-			res = v.app.DB().Create(&task)
+			return SetTaskTags(tx, task.ID, tagsValue())
+		})
+		if err != nil {
+			v.app.ReportError(fmt.Sprintf("Error saving %s", task.Label), err, save)
+			return
 		}
-		if res.Error != nil {
-			panic(fmt.Sprintf("Error saving task: %v", res.Error))
+
+		if v.task != nil {
+			// the views share v.task, so undoing puts it back too rather than leaving them to show the edit.
+			edited, before := v.task, *v.task
+			undo := undoTaskUpdate(before, currentSubtasks, currentTags)
+			v.app.RecordUndo(fmt.Sprintf("Edited %s", task.Label), func(db *gorm.DB) error {
+				if err := undo(db); err != nil {
+					return err
+				}
+				*edited = before
+				return nil
+			})
+			*v.task = task
+		} else {
+			taskID := task.ID
+			v.app.RecordUndo(fmt.Sprintf("Created %s", task.Label), func(db *gorm.DB) error {
+				return PurgeTask(db, taskID)
+			})
 		}
 
-		v.app.RenderPreviousView()
-	}))
+		v.app.Back()
+	}
+	saveBtn := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), save)
+	form.SetSubmit(saveBtn)
+	ftr.Add(saveBtn)
 
 	return container.NewBorder(
 		nil,
@@ -272,6 +373,19 @@ func (v *MutateTaskView) Foreground() fyne.CanvasObject {
 	)
 }
 
+func (v *MutateTaskView) delete() {
+	res := v.app.DB().Delete(v.task)
+	if res.Error != nil {
+		v.app.ReportError(fmt.Sprintf("Error deleting %s", v.task.Label), res.Error, v.delete)
+		return
+	}
+	taskID := v.task.ID
+	v.app.RecordUndo(fmt.Sprintf("Deleted %s", v.task.Label), func(db *gorm.DB) error {
+		return RestoreTask(db, taskID)
+	})
+	v.app.BackFrom(TaskRoute(taskID))
+}
+
 func (v *MutateTaskView) Background() {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"image/color"
+	"slices"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -14,6 +18,11 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	listColorNone   = "None"
+	listColorCustom = "Custom"
+)
+
 var _ View = (*MutateTaskListView)(nil)
 
 type MutateTaskListView struct {
@@ -29,6 +38,13 @@ func NewMutateTaskListView(app *TaskApp, taskList *TaskList) *MutateTaskListView
 	return &v
 }
 
+func (v *MutateTaskListView) Route() string {
+	if v.taskList == nil {
+		return RouteNewList
+	}
+	return EditTaskListRoute(v.taskList.ID)
+}
+
 func (v *MutateTaskListView) Title() []fyne.CanvasObject {
 	var title *canvas.Text
 	if v.taskList == nil {
@@ -44,97 +60,216 @@ func (v *MutateTaskListView) Foreground() fyne.CanvasObject {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	if v.foreground() {
-		return v.render(nil)
+		return v.render()
 	}
 	return nil
 }
 
+func (v *MutateTaskListView) delete() {
+	if err := DeleteTaskList(v.app.DB(), v.taskList); err != nil {
+		v.app.ReportError(fmt.Sprintf("Error deleting %s", v.taskList.Label), err, v.delete)
+		return
+	}
+	taskListID := v.taskList.ID
+	v.app.RecordUndo(fmt.Sprintf("Deleted %s", v.taskList.Label), func(db *gorm.DB) error {
+		return RestoreTaskList(db, taskListID)
+	})
+	v.app.BackFrom(TaskListRoute(taskListID))
+}
+
 func (v *MutateTaskListView) Background() {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	v.background()
 }
 
-func (v *MutateTaskListView) render(err error) fyne.CanvasObject {
-	content := container.NewVBox()
-
+func (v *MutateTaskListView) render() fyne.CanvasObject {
+	taskLists, err := FindModel[TaskList](context.Background(), v.app.DB())
 	if err != nil {
-		content.Add(canvas.NewText("Error:", ColorRed))
-		content.Add(canvas.NewText(err.Error(), ColorRed))
+		return v.app.loadFailed("Error loading task lists", err)
+	}
+
+	var taskListID uint
+	if v.taskList != nil {
+		taskListID = v.taskList.ID
 	}
 
-	content.Add(canvas.NewText("Name:", color.Black))
+	form := newFormValidator()
+	content := container.NewVBox()
+
+	content.Add(canvas.NewText("Name:", theme.Color(theme.ColorNameForeground)))
 
 	labelInput := widget.NewEntry()
 	labelInput.PlaceHolder = "Enter task list name."
-	labelInput.OnChanged = func(s string) {
-		if len(s) > 50 {
-			labelInput.SetText(s[:50])
-		}
-	}
 	if v.taskList != nil {
 		labelInput.SetText(v.taskList.Label)
 	}
 
-	content.Add(labelInput)
+	content.Add(form.Entry(
+		labelInput,
+		MaxLabelLength,
+		AllValidators(RequiredValidator("Name"), MaxLengthValidator("Name", MaxLabelLength)),
+	))
+	content.Add(form.Warn(func() error {
+		if dup := DuplicateTaskListLabel(labelInput.Text, taskListID, taskLists); dup != nil {
+			return fmt.Errorf("a list named %s already exists", dup.Label)
+		}
+		return nil
+	}))
 
-	content.Add(canvas.NewText("Description:", color.Black))
+	content.Add(canvas.NewText("Description:", theme.Color(theme.ColorNameForeground)))
 
 	descInput := widget.NewMultiLineEntry()
 	descInput.PlaceHolder = "Enter Markdown formatted text."
-	descInput.OnChanged = func(s string) {
-		if len(s) > 500 {
-			descInput.SetText(s[:500])
-		}
-	}
 	if v.taskList != nil {
 		descInput.SetText(v.taskList.Description)
 	}
 
-	content.Add(descInput)
+	content.Add(form.Entry(descInput, MaxDescriptionLength, MaxLengthValidator("Description", MaxDescriptionLength)))
+
+	content.Add(canvas.NewText("Color:", theme.Color(theme.ColorNameForeground)))
+
+	var currentColor string
+	if v.taskList != nil {
+		currentColor = NormalizeListColor(v.taskList.Color)
+	}
+
+	colorOptions := []string{listColorNone}
+	for _, name := range ListColorNames {
+		colorOptions = append(colorOptions, strings.ToUpper(name[:1])+name[1:])
+	}
+	colorOptions = append(colorOptions, listColorCustom)
+
+	colorSwatch := canvas.NewRectangle(color.Transparent)
+	colorSwatch.CornerRadius = 4
+	colorSwatch.SetMinSize(fyne.NewSize(24, 24))
+
+	hexInput := widget.NewEntry()
+	hexInput.PlaceHolder = "#rrggbb"
+	colorSelect := widget.NewSelect(colorOptions, nil)
+
+	// selectedColor is the color as it will be saved, empty for none.
+	selectedColor := func() string {
+		switch idx := colorSelect.SelectedIndex(); {
+		case idx <= 0:
+			return ""
+		case colorSelect.Selected == listColorCustom:
+			return NormalizeListColor(hexInput.Text)
+		default:
+			return ListColorNames[idx-1]
+		}
+	}
+	updateSwatch := func() {
+		colorSwatch.FillColor = color.Transparent
+		if c, ok, err := ParseListColor(selectedColor()); ok && err == nil {
+			colorSwatch.FillColor = c
+		}
+		colorSwatch.Refresh()
+	}
+
+	hexInput.OnChanged = func(string) {
+		updateSwatch()
+	}
+	hexField := form.Entry(hexInput, 0, func(s string) error {
+		if colorSelect.Selected != listColorCustom {
+			return nil
+		}
+		if strings.TrimSpace(s) == "" {
+			return errors.New("enter a color such as #8b81fd")
+		}
+		return ValidateListColor(s)
+	})
+
+	colorSelect.OnChanged = func(s string) {
+		if s == listColorCustom {
+			hexField.Show()
+		} else {
+			hexField.Hide()
+		}
+		updateSwatch()
+		form.Validate()
+	}
+	switch {
+	case currentColor == "":
+		colorSelect.SetSelectedIndex(0)
+	case slices.Contains(ListColorNames, currentColor):
+		colorSelect.SetSelectedIndex(slices.Index(ListColorNames, currentColor) + 1)
+	default:
+		hexInput.SetText(currentColor)
+		colorSelect.SetSelected(listColorCustom)
+	}
+
+	content.Add(container.NewBorder(nil, nil, nil, colorSwatch, colorSelect))
+	content.Add(hexField)
 
 	ftr := container.NewHBox(layout.NewSpacer())
 
 	if v.taskList != nil {
-		ftr.Add(widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
-			res := v.app.DB().Delete(v.taskList)
-			if res.Error != nil {
-				panic(fmt.Sprintf("Error deleting task list %d: %v", v.taskList.ID, res.Error))
-			}
-			v.app.RenderTaskListsView()
-		}))
+		ftr.Add(widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), v.delete))
 	}
 
-	ftr.Add(widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), func() {
-		v.app.RenderPreviousView()
-	}))
-	ftr.Add(widget.NewButtonWithIcon(
-		"Save",
-		theme.DocumentSaveIcon(),
-		func() {
-			var res *gorm.DB
-			if v.taskList != nil {
-				v.taskList.Label = labelInput.Text
-				v.taskList.Description = descInput.Text
-				res = v.app.DB().Updates(v.taskList)
-			} else {
-				v.taskList = &TaskList{
-					Label:       labelInput.Text,
-					Date:        time.Now(),
-					Description: descInput.Text,
+	ftr.Add(widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), v.app.Back))
+
+	var save func()
+	save = func() {
+		if !form.Validate() {
+			return
+		}
+
+		var undo UndoFunc
+		created := v.taskList == nil
+		if !created {
+			edited, before := v.taskList, *v.taskList
+			taskList := *v.taskList
+			taskList.Label = strings.TrimSpace(labelInput.Text)
+			taskList.Description = descInput.Text
+			taskList.Color = selectedColor()
+			// Select, as Updates would otherwise skip a cleared description or color.
+			res := v.app.DB().Model(&taskList).Select("Label", "Description", "Color").Updates(&taskList)
+			if res.Error != nil {
+				v.app.ReportError(fmt.Sprintf("Error saving %s", taskList.Label), res.Error, save)
+				return
+			}
+			undo = func(db *gorm.DB) error {
+				res := db.Model(&TaskList{Model: gorm.Model{ID: before.ID}}).
+					Select("Label", "Description", "Color").
+					Updates(&before)
+				if res.Error != nil {
+					return res.Error
 				}
-				res = v.app.DB().Create(v.taskList)
+				// the views share v.taskList, so it is put back too.
+				*edited = before
+				return nil
 			}
-			if res.Error != nil {
-				v.render(err)
+			*v.taskList = taskList
+		} else {
+			taskList := TaskList{
+				Label:       strings.TrimSpace(labelInput.Text),
+				Date:        time.Now(),
+				Description: descInput.Text,
+				Color:       selectedColor(),
+			}
+			if res := v.app.DB().Create(&taskList); res.Error != nil {
+				v.app.ReportError(fmt.Sprintf("Error saving %s", taskList.Label), res.Error, save)
 				return
 			}
+			undo = func(db *gorm.DB) error {
+				return PurgeTaskList(db, taskList.ID)
+			}
+			v.taskList = &taskList
+		}
 
-			v.app.RenderListOfTasksView(v.taskList.Label, v.taskList, func(db *gorm.DB) *gorm.DB {
-				return db.Where("task_list_id = ?", v.taskList.ID)
-			})
-		},
-	))
+		if created {
+			v.app.RecordUndoLeaving(fmt.Sprintf("Saved %s", v.taskList.Label), TaskListRoute(v.taskList.ID), undo)
+			v.app.ReplaceWithTaskListTasksView(*v.taskList)
+		} else {
+			v.app.RecordUndo(fmt.Sprintf("Saved %s", v.taskList.Label), undo)
+			v.app.Back()
+		}
+	}
+	saveBtn := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), save)
+	form.SetSubmit(saveBtn)
+	ftr.Add(saveBtn)
 
 	return container.NewBorder(
 		nil,
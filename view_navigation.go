@@ -6,7 +6,6 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
-	"gorm.io/gorm"
 )
 
 var _ View = (*NavigationView)(nil)
@@ -22,12 +21,16 @@ func NewNavigationView(ta *TaskApp) *NavigationView {
 	return &v
 }
 
+func (v *NavigationView) Route() string {
+	return RouteNavigation
+}
+
 func (v *NavigationView) Title() []fyne.CanvasObject {
 	return []fyne.CanvasObject{
 		HeaderCanvas("Navigation"),
 		layout.NewSpacer(),
 		widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
-			v.app.RenderPreviousView()
+			v.app.Back()
 		}),
 	}
 }
@@ -48,6 +51,9 @@ func (v *NavigationView) Foreground() fyne.CanvasObject {
 			widget.NewButtonWithIcon("Home", theme.HomeIcon(), func() {
 				v.app.RenderHomeView()
 			}),
+			widget.NewButtonWithIcon("Search", theme.SearchIcon(), func() {
+				v.app.RenderSearchView("", "")
+			}),
 
 			widget.NewSeparator(),
 			widget.NewSeparator(),
@@ -56,25 +62,48 @@ func (v *NavigationView) Foreground() fyne.CanvasObject {
 			widget.NewButton("Lists", func() {
 				v.app.RenderTaskListsView()
 			}),
+			widget.NewButton("Tags", func() {
+				v.app.RenderTagsView()
+			}),
 
 			widget.NewSeparator(),
 			widget.NewSeparator(),
 			widget.NewSeparator(),
 
 			widget.NewButton("Today's Tasks", func() {
-				v.app.RenderListOfTasksView("Today's Tasks", nil, todaysTasksModelQueryOpt())
+				v.app.RenderTodaysTasksView()
+			}),
+			widget.NewButtonWithIcon("Calendar", theme.CalendarIcon(), func() {
+				v.app.RenderCalendarView()
+			}),
+			widget.NewButton("Agenda", func() {
+				v.app.RenderAgendaView()
 			}),
 			widget.NewButton("Todo Tasks", func() {
-				v.app.RenderListOfTasksView("Todo Tasks", nil, func(db *gorm.DB) *gorm.DB {
-					return WithSort("due_date asc")(WithSort("id asc")(WithPreload("TaskList")(db))).
-						Where("Status = ?", TaskStatusTodo)
-				})
+				v.app.RenderStatusTasksView("todo")
+			}),
+			widget.NewButton("In Progress Tasks", func() {
+				v.app.RenderStatusTasksView("in-progress")
 			}),
 			widget.NewButton("Done Tasks", func() {
-				v.app.RenderListOfTasksView("Done Tasks", nil, func(db *gorm.DB) *gorm.DB {
-					return WithSort("due_date asc")(WithSort("id asc")(WithPreload("TaskList")(db))).
-						Where("Status in ?", []uint{TaskStatusSkip, TaskStatusDone})
-				})
+				v.app.RenderStatusTasksView("done")
+			}),
+
+			widget.NewSeparator(),
+			widget.NewSeparator(),
+			widget.NewSeparator(),
+
+			widget.NewButtonWithIcon("Trash", theme.DeleteIcon(), func() {
+				v.app.RenderTrashView()
+			}),
+			widget.NewButtonWithIcon("Data", theme.StorageIcon(), func() {
+				v.app.RenderDataView()
+			}),
+			widget.NewButtonWithIcon("Backups", theme.HistoryIcon(), func() {
+				v.app.RenderBackupsView()
+			}),
+			widget.NewButtonWithIcon("Settings", theme.SettingsIcon(), func() {
+				v.app.RenderSettingsView()
 			}),
 
 			widget.NewSeparator(),
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
+)
+
+const (
+	searchFilterAll   = "Everything"
+	searchFilterLists = "Lists"
+	searchFilterToday = "Today's tasks"
+)
+
+var (
+	searchFilters = append([]string{searchFilterAll, searchFilterLists, searchFilterToday}, TaskStatusTitles...)
+)
+
+var _ View = (*SearchView)(nil)
+
+// SearchView searches the labels and descriptions of tasks and lists.
+type SearchView struct {
+	*baseView
+	query  string
+	filter string
+}
+
+func NewSearchView(ta *TaskApp, query, filter string) *SearchView {
+	if filter == "" {
+		filter = searchFilterAll
+	}
+	v := SearchView{
+		baseView: newBaseView("Search", ta),
+		query:    query,
+		filter:   filter,
+	}
+	return &v
+}
+
+func (v *SearchView) Route() string {
+	return SearchRoute(v.query, v.filter)
+}
+
+func (v *SearchView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Search")}
+}
+
+// taskOpts returns the filter to combine with the search, and false when the filter excludes tasks.
+func (v *SearchView) taskOpts() ([]ModelQueryOpt, bool) {
+	switch v.filter {
+	case searchFilterAll:
+		return nil, true
+	case searchFilterLists:
+		return nil, false
+	case searchFilterToday:
+		return []ModelQueryOpt{todaysTasksModelQueryOpt()}, true
+
+	default:
+		status := TaskStatusNumber(v.filter)
+		return []ModelQueryOpt{func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", status)
+		}}, true
+	}
+}
+
+func (v *SearchView) search(ctx context.Context) ([]SearchResult, error) {
+	var results []SearchResult
+
+	if v.filter == searchFilterAll || v.filter == searchFilterLists {
+		lists, err := SearchTaskLists(ctx, v.app.DB(), v.query)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, lists...)
+	}
+
+	if opts, ok := v.taskOpts(); ok {
+		tasks, err := SearchTasks(ctx, v.app.DB(), v.query, opts...)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, tasks...)
+	}
+
+	return results, nil
+}
+
+func (v *SearchView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.foreground() {
+		return nil
+	}
+
+	results := container.NewVBox()
+	resultCount := widget.NewLabel("")
+
+	var refresh func()
+	refresh = func() {
+		results.RemoveAll()
+		found, err := v.search(context.Background())
+		if err != nil {
+			v.app.ReportError("Error searching", err, refresh)
+			return
+		}
+		for i := range found {
+			results.Add(v.resultRow(found[i]))
+		}
+		switch {
+		case SearchMatchQuery(v.query) == "":
+			resultCount.SetText("Type to search tasks and lists")
+		case len(found) == 0:
+			resultCount.SetText("No matches")
+		default:
+			resultCount.SetText(fmt.Sprintf("%d matches", len(found)))
+		}
+	}
+
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder("Search...")
+	searchEntry.SetText(v.query)
+	searchEntry.OnChanged = func(s string) {
+		v.query = s
+		refresh()
+	}
+
+	filterSelect := widget.NewSelect(searchFilters, nil)
+	filterSelect.SetSelected(v.filter)
+	filterSelect.OnChanged = func(s string) {
+		v.filter = s
+		refresh()
+	}
+
+	refresh()
+
+	return container.NewBorder(
+		container.NewVBox(
+			container.NewBorder(nil, nil, widget.NewIcon(theme.SearchIcon()), nil, searchEntry),
+			container.NewBorder(nil, nil, FormLabel("Show:"), nil, filterSelect),
+		),
+		resultCount,
+		nil,
+		nil,
+		container.NewVScroll(results),
+	)
+}
+
+func (v *SearchView) resultRow(res SearchResult) fyne.CanvasObject {
+	var (
+		icon  fyne.Resource
+		where string
+		chip  fyne.CanvasObject
+		open  func()
+	)
+
+	if res.Task != nil {
+		task := *res.Task
+		icon = TaskStatusResource(task.Status)
+		if task.TaskList != nil {
+			where = task.TaskList.Label
+		}
+		chip = ListColorChip(task.TaskList)
+		open = func() {
+			v.app.RenderTaskView(task)
+		}
+	} else {
+		taskList := *res.TaskList
+		icon = theme.ListIcon()
+		where = "List"
+		chip = ListColorChip(&taskList)
+		open = func() {
+			v.app.RenderTaskListView(taskList)
+		}
+	}
+
+	text := container.NewVBox(widget.NewRichTextFromMarkdown(res.Label))
+	if res.Snippet != "" {
+		snippet := widget.NewRichTextFromMarkdown(res.Snippet)
+		snippet.Wrapping = fyne.TextWrapWord
+		text.Add(snippet)
+	}
+	if where != "" {
+		text.Add(FormLabel(where))
+	}
+
+	return container.NewStack(
+		widget.NewButton("", open),
+		container.NewBorder(nil, nil, widget.NewIcon(icon), chip, text),
+	)
+}
+
+func (v *SearchView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
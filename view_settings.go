@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	settingsLastViewedList = "Last viewed list"
+)
+
+var _ View = (*SettingsView)(nil)
+
+// SettingsView edits the user's Settings, saving each change as it is made.
+type SettingsView struct {
+	*baseView
+}
+
+func NewSettingsView(ta *TaskApp) *SettingsView {
+	v := SettingsView{
+		baseView: newBaseView("Settings", ta),
+	}
+	return &v
+}
+
+func (v *SettingsView) Route() string {
+	return RouteSettings
+}
+
+func (v *SettingsView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Settings")}
+}
+
+func (v *SettingsView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.foreground() {
+		return nil
+	}
+
+	taskLists, err := FindModel[TaskList](context.Background(), v.app.DB(), WithSort("label asc"))
+	if err != nil {
+		return v.app.loadFailed("Error loading task lists", err)
+	}
+
+	settings := v.app.Settings()
+	save := func() {
+		v.app.SaveSettings(settings)
+	}
+
+	prioritySelect := widget.NewSelect(TaskPriorities, nil)
+	prioritySelect.SetSelected(settings.DefaultPriority)
+	prioritySelect.OnChanged = func(s string) {
+		settings.DefaultPriority = s
+		save()
+	}
+
+	listNames := []string{settingsLastViewedList}
+	for _, tl := range taskLists {
+		listNames = append(listNames, tl.Label)
+	}
+	listSelect := widget.NewSelect(listNames, nil)
+	listSelect.SetSelected(settingsLastViewedList)
+	for _, tl := range taskLists {
+		if tl.ID == settings.DefaultTaskListID {
+			listSelect.SetSelected(tl.Label)
+		}
+	}
+	listSelect.OnChanged = func(string) {
+		settings.DefaultTaskListID = 0
+		if idx := listSelect.SelectedIndex(); idx > 0 {
+			settings.DefaultTaskListID = taskLists[idx-1].ID
+		}
+		save()
+	}
+
+	dueSelect := widget.NewSelect(DefaultDueOptions, nil)
+	dueSelect.SetSelected(settings.DefaultDue)
+	dueSelect.OnChanged = func(s string) {
+		settings.DefaultDue = s
+		save()
+	}
+
+	// formats are shown as an example rather than as a layout.
+	example := time.Date(time.Now().Year(), time.December, 31, 16, 30, 0, 0, time.Local)
+	dateFormats := make([]string, len(DateFormatOptions))
+	for i, layout := range DateFormatOptions {
+		dateFormats[i] = example.Format(layout)
+	}
+	timeFormats := make([]string, len(TimeFormatOptions))
+	for i, layout := range TimeFormatOptions {
+		timeFormats[i] = example.Format(layout)
+	}
+
+	preview := widget.NewLabel("")
+	updatePreview := func() {
+		preview.SetText("Example: " + FormatDateTime(time.Now()))
+	}
+	updatePreview()
+
+	dateSelect := widget.NewSelect(dateFormats, nil)
+	dateSelect.SetSelectedIndex(slices.Index(DateFormatOptions, settings.DateFormat))
+	dateSelect.OnChanged = func(string) {
+		settings.DateFormat = DateFormatOptions[dateSelect.SelectedIndex()]
+		save()
+		updatePreview()
+	}
+
+	timeSelect := widget.NewSelect(timeFormats, nil)
+	timeSelect.SetSelectedIndex(slices.Index(TimeFormatOptions, settings.TimeFormat))
+	timeSelect.OnChanged = func(string) {
+		settings.TimeFormat = TimeFormatOptions[timeSelect.SelectedIndex()]
+		save()
+		updatePreview()
+	}
+
+	weekdays := make([]string, len(FirstWeekdayOptions))
+	for i, day := range FirstWeekdayOptions {
+		weekdays[i] = day.String()
+	}
+	weekdaySelect := widget.NewSelect(weekdays, nil)
+	weekdaySelect.SetSelected(settings.FirstWeekday.String())
+	weekdaySelect.OnChanged = func(string) {
+		settings.FirstWeekday = FirstWeekdayOptions[weekdaySelect.SelectedIndex()]
+		save()
+	}
+
+	themeSelect := widget.NewSelect(ThemeVariantOptions, nil)
+	themeSelect.SetSelected(settings.ThemeVariant)
+	themeSelect.OnChanged = func(s string) {
+		settings.ThemeVariant = s
+		save()
+	}
+
+	resizableCheck := widget.NewCheck("Resizable window", nil)
+	resizableCheck.SetChecked(LoadWindowState(v.app.fyneApp.Preferences()).Resizable)
+	resizableCheck.OnChanged = func(b bool) {
+		state := LoadWindowState(v.app.fyneApp.Preferences())
+		state.Resizable = b
+		state.Size = v.app.window.Canvas().Size()
+		state.Save(v.app.fyneApp.Preferences())
+		v.app.window.SetFixedSize(!b)
+	}
+
+	return container.NewVScroll(
+		container.NewVBox(
+			FormLabel("New tasks"),
+			FormLabel("Priority:"),
+			prioritySelect,
+			FormLabel("List:"),
+			listSelect,
+			FormLabel("Due:"),
+			dueSelect,
+
+			widget.NewSeparator(),
+
+			FormLabel("Dates"),
+			FormLabel("Date format:"),
+			dateSelect,
+			FormLabel("Time format:"),
+			timeSelect,
+			preview,
+			FormLabel("First day of the week:"),
+			weekdaySelect,
+
+			widget.NewSeparator(),
+
+			FormLabel("Appearance"),
+			FormLabel("Theme:"),
+			themeSelect,
+			resizableCheck,
+		),
+	)
+}
+
+func (v *SettingsView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+var _ View = (*TagsView)(nil)
+
+type TagsView struct {
+	*baseView
+}
+
+func NewTagsView(ta *TaskApp) *TagsView {
+	v := TagsView{
+		baseView: newBaseView("Tags", ta),
+	}
+	return &v
+}
+
+func (v *TagsView) Route() string {
+	return RouteTags
+}
+
+func (v *TagsView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Tags")}
+}
+
+func (v *TagsView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.foreground() {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-v.deactivated
+		cancel()
+	}()
+
+	tags, err := FindModel[Tag](ctx, v.app.DB(), WithSort("label asc"))
+	if err != nil {
+		return v.app.loadFailed("Error loading tags", err)
+	}
+
+	taskCounts := make([]int64, len(tags))
+	for i := range tags {
+		taskCounts[i], err = CountAssociation(ctx, v.app.DB(), tags[i], "Tasks")
+		if err != nil {
+			return v.app.loadFailed(fmt.Sprintf("Error counting tasks tagged %s", tags[i].Label), err)
+		}
+	}
+
+	listView := widget.NewList(
+		func() int {
+			return len(tags)
+		},
+		func() fyne.CanvasObject {
+			return container.NewStack(widget.NewLabel("Loading..."))
+		},
+		func(id widget.ListItemID, object fyne.CanvasObject) {
+			content := object.(*fyne.Container)
+
+			content.RemoveAll()
+
+			labelText := canvas.NewText("#"+tags[id].Label, theme.Color(theme.ColorNameForeground))
+			ResizeTextToFit(labelText, 14, 275)
+
+			content.Add(container.NewBorder(
+				nil,
+				nil,
+				nil,
+				canvas.NewText(fmt.Sprintf("%d", taskCounts[id]), theme.Color(theme.ColorNameForeground)),
+				labelText,
+			))
+		},
+	)
+
+	listView.OnSelected = func(id widget.ListItemID) {
+		v.app.RenderTagTasksView(tags[id])
+	}
+
+	ftr := container.NewHBox(
+		canvas.NewText(fmt.Sprintf("Total tags: %d", len(tags)), theme.Color(theme.ColorNameForeground)),
+	)
+
+	return container.NewBorder(
+		nil,
+		ftr,
+		nil,
+		nil,
+		listView,
+	)
+}
+
+func (v *TagsView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
@@ -9,25 +9,28 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
 )
 
 var _ View = (*TaskView)(nil)
 
 type TaskView struct {
 	*baseView
-	task     Task
-	onDelete func()
+	task Task
 }
 
-func NewTaskView(ta *TaskApp, task Task, onDelete func()) *TaskView {
+func NewTaskView(ta *TaskApp, task Task) *TaskView {
 	v := TaskView{
 		baseView: newBaseView("Task View", ta),
 		task:     task,
-		onDelete: onDelete,
 	}
 	return &v
 }
 
+func (v *TaskView) Route() string {
+	return TaskRoute(v.task.ID)
+}
+
 func (v *TaskView) Title() []fyne.CanvasObject {
 	title := HeaderCanvas(v.task.Label)
 	ResizeTextToFit(title, 32, 350)
@@ -51,22 +54,55 @@ func (v *TaskView) Foreground() fyne.CanvasObject {
 
 	hdr := container.NewHBox(
 		layout.NewSpacer(),
-		newTaskPrioritySwitcherButton(v.app.DB(), &v.task),
-		newTaskStatusSwitcherButton(v.app.DB(), &v.task),
+		newTaskPrioritySwitcherButton(v.app, &v.task),
+		newTaskStatusSwitcherButton(v.app, &v.task),
 	)
 
+	taskList, err := GetListForTask(ctx, v.app.DB(), v.task)
+	if err != nil {
+		return v.app.loadFailed("Error loading the task's list", err)
+	}
+	listLabel := "None"
+	if taskList != nil {
+		listLabel = taskList.Label
+	}
+
 	body := container.NewVBox(
 		FormLabel("List:"),
-		widget.NewLabel(func() string {
-			taskList := GetListForTask(ctx, v.app.DB(), v.task)
-			if taskList == nil {
-				return "None"
-			}
-			return taskList.Label
-		}()),
+		widget.NewLabel(listLabel),
 		FormLabel("Due Date:"),
 		widget.NewLabel(FormatDateTime(v.task.DueDate)),
-		FormLabel("Description:"),
+	)
+
+	if v.task.Recurrence != "" {
+		body.Add(FormLabel("Repeats:"))
+		if rule, err := ParseTaskRecurrence(v.task.Recurrence); err != nil {
+			body.Add(widget.NewLabel(v.task.Recurrence))
+		} else {
+			body.Add(widget.NewLabel(rule.Describe()))
+		}
+	}
+
+	subtasks, err := FindModel[Subtask](ctx, v.app.DB(), taskSubtasksModelQueryOpt(v.task.ID))
+	if err != nil {
+		return v.app.loadFailed("Error loading the task's checklist", err)
+	}
+	if len(subtasks) > 0 {
+		body.Add(FormLabel("Checklist:"))
+		body.Add(newSubtaskChecklist(v.app, subtasks))
+	}
+
+	tags, err := FindAssociation[Task, Tag](ctx, v.app.DB(), v.task, "Tags")
+	if err != nil {
+		return v.app.loadFailed("Error loading the task's tags", err)
+	}
+	if len(tags) > 0 {
+		body.Add(FormLabel("Tags:"))
+		body.Add(newTagChips(v.app, tags))
+	}
+
+	body.Add(FormLabel("Description:"))
+	body.Add(
 		container.NewHScroll(
 			widget.NewRichTextFromMarkdown(v.task.Description),
 		),
@@ -74,15 +110,9 @@ func (v *TaskView) Foreground() fyne.CanvasObject {
 
 	ftr := container.NewHBox(
 		layout.NewSpacer(),
-		widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
-			res := v.app.DB().Delete(v.task)
-			if res.Error != nil {
-				panic(fmt.Sprintf("Error deleting task %d: %v", v.task.ID, res.Error))
-			}
-			v.onDelete()
-		}),
+		widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), v.delete),
 		widget.NewButtonWithIcon("Edit", IconEdit, func() {
-			v.app.RenderMutateTaskView(&v.task, nil, v.onDelete)
+			v.app.RenderMutateTaskView(&v.task, nil)
 		}),
 	)
 
@@ -95,6 +125,19 @@ func (v *TaskView) Foreground() fyne.CanvasObject {
 	)
 }
 
+func (v *TaskView) delete() {
+	res := v.app.DB().Delete(v.task)
+	if res.Error != nil {
+		v.app.ReportError(fmt.Sprintf("Error deleting %s", v.task.Label), res.Error, v.delete)
+		return
+	}
+	taskID := v.task.ID
+	v.app.RecordUndo(fmt.Sprintf("Deleted %s", v.task.Label), func(db *gorm.DB) error {
+		return RestoreTask(db, taskID)
+	})
+	v.app.BackFrom(TaskRoute(taskID))
+}
+
 func (v *TaskView) Background() {
 	v.mu.Lock()
 	defer v.mu.Unlock()
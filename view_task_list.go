@@ -16,22 +16,24 @@ var _ View = (*TaskListView)(nil)
 type TaskListView struct {
 	*baseView
 	taskList TaskList
-	onDelete func()
 }
 
-func NewTaskListView(ta *TaskApp, taskList TaskList, onDelete func()) *TaskListView {
+func NewTaskListView(ta *TaskApp, taskList TaskList) *TaskListView {
 	v := TaskListView{
 		baseView: newBaseView("Task List View", ta),
 		taskList: taskList,
-		onDelete: onDelete,
 	}
 	return &v
 }
 
+func (v *TaskListView) Route() string {
+	return TaskListRoute(v.taskList.ID)
+}
+
 func (v *TaskListView) Title() []fyne.CanvasObject {
 	title := HeaderCanvas(v.taskList.Label)
 	ResizeTextToFit(title, 32, 350)
-	return []fyne.CanvasObject{title}
+	return []fyne.CanvasObject{ListColorTint(v.taskList, title)}
 }
 
 func (v *TaskListView) Foreground() fyne.CanvasObject {
@@ -50,22 +52,12 @@ func (v *TaskListView) Foreground() fyne.CanvasObject {
 
 	ftr := container.NewHBox(
 		layout.NewSpacer(),
-		widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
-			res := v.app.DB().Delete(v.taskList)
-			if res.Error != nil {
-				panic(fmt.Sprintf("Error deleting task list %d: %v", v.taskList.ID, res.Error))
-			}
-			v.app.RenderTaskListsView()
-		}),
+		widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), v.delete),
 		widget.NewButtonWithIcon("Edit", IconEdit, func() {
 			v.app.RenderMutateTaskListView(&v.taskList)
 		}),
 		widget.NewButtonWithIcon("New task", theme.ContentAddIcon(), func() {
-			v.app.RenderMutateTaskView(nil, &v.taskList, func() {
-				v.app.RenderListOfTasksView(v.Name(), &v.taskList, func(db *gorm.DB) *gorm.DB {
-					return db.Where("task_list_id = ?", v.taskList.ID)
-				})
-			})
+			v.app.RenderMutateTaskView(nil, &v.taskList)
 		}),
 	)
 
@@ -78,6 +70,18 @@ func (v *TaskListView) Foreground() fyne.CanvasObject {
 	)
 }
 
+func (v *TaskListView) delete() {
+	if err := DeleteTaskList(v.app.DB(), &v.taskList); err != nil {
+		v.app.ReportError(fmt.Sprintf("Error deleting %s", v.taskList.Label), err, v.delete)
+		return
+	}
+	taskListID := v.taskList.ID
+	v.app.RecordUndo(fmt.Sprintf("Deleted %s", v.taskList.Label), func(db *gorm.DB) error {
+		return RestoreTaskList(db, taskListID)
+	})
+	v.app.BackFrom(TaskListRoute(taskListID))
+}
+
 func (v *TaskListView) Background() {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -3,14 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
-	"image/color"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
-	"gorm.io/gorm"
 )
 
 var _ View = (*TaskListsView)(nil)
@@ -26,6 +24,10 @@ func NewTaskListsView(ta *TaskApp) *TaskListsView {
 	return &v
 }
 
+func (v *TaskListsView) Route() string {
+	return RouteLists
+}
+
 func (v *TaskListsView) Title() []fyne.CanvasObject {
 	return []fyne.CanvasObject{HeaderCanvas("Task Lists")}
 }
@@ -47,12 +49,12 @@ func (v *TaskListsView) Foreground() fyne.CanvasObject {
 
 	listCount, err := CountModel[TaskList](ctx, v.app.DB())
 	if err != nil {
-		panic(fmt.Sprintf("Error counting task lists: %v", err))
+		return v.app.loadFailed("Error counting task lists", err)
 	}
 
 	taskLists, err := FindModel[TaskList](ctx, v.app.DB())
 	if err != nil {
-		panic(fmt.Sprintf("Error fetching tasks: %v", err))
+		return v.app.loadFailed("Error loading task lists", err)
 	}
 
 	listView := widget.NewList(
@@ -69,18 +71,16 @@ func (v *TaskListsView) Foreground() fyne.CanvasObject {
 
 			content.RemoveAll()
 
-			labelText := canvas.NewText(taskList.Label, color.Black)
+			labelText := canvas.NewText(taskList.Label, theme.Color(theme.ColorNameForeground))
 			ResizeTextToFit(labelText, 14, 275)
 
 			content.Add(container.NewBorder(
 				nil,
 				nil,
-				nil,
+				ListColorStripe(taskList),
 				container.NewHBox(
 					widget.NewButtonWithIcon("", theme.ListIcon(), func() {
-						v.app.RenderListOfTasksView(taskList.Label, &taskList, func(db *gorm.DB) *gorm.DB {
-							return db.Where("task_list_id = ?", taskList.ID)
-						})
+						v.app.RenderTaskListTasksView(taskList)
 					}),
 					widget.NewButtonWithIcon("", IconEdit, func() {
 						v.app.RenderMutateTaskListView(&taskList)
@@ -92,13 +92,13 @@ func (v *TaskListsView) Foreground() fyne.CanvasObject {
 	)
 
 	listView.OnSelected = func(id widget.ListItemID) {
-		v.app.RenderTaskListView(taskLists[id], v.app.RenderTaskListsView)
+		v.app.RenderTaskListView(taskLists[id])
 	}
 
 	ftr := container.NewBorder(
 		nil,
 		nil,
-		canvas.NewText(fmt.Sprintf("Total lists: %d", listCount), color.Black),
+		canvas.NewText(fmt.Sprintf("Total lists: %d", listCount), theme.Color(theme.ColorNameForeground)),
 		widget.NewButtonWithIcon("New list", theme.ContentAddIcon(), func() {
 			v.app.RenderMutateTaskListView(nil)
 		}),
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+type trashEntry struct {
+	id        uint
+	label     string
+	isList    bool
+	deletedAt time.Time
+}
+
+var _ View = (*TrashView)(nil)
+
+type TrashView struct {
+	*baseView
+}
+
+func NewTrashView(ta *TaskApp) *TrashView {
+	v := TrashView{
+		baseView: newBaseView("Trash", ta),
+	}
+	return &v
+}
+
+func (v *TrashView) Route() string {
+	return RouteTrash
+}
+
+func (v *TrashView) Title() []fyne.CanvasObject {
+	return []fyne.CanvasObject{HeaderCanvas("Trash")}
+}
+
+func (v *TrashView) Foreground() fyne.CanvasObject {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.foreground() {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-v.deactivated
+		cancel()
+	}()
+
+	taskLists, err := FindModel[TaskList](ctx, v.app.DB(), trashedModelQueryOpt())
+	if err != nil {
+		return v.app.loadFailed("Error loading deleted task lists", err)
+	}
+
+	tasks, err := FindModel[Task](ctx, v.app.DB(), trashedModelQueryOpt())
+	if err != nil {
+		return v.app.loadFailed("Error loading deleted tasks", err)
+	}
+
+	entries := make([]trashEntry, 0, len(taskLists)+len(tasks))
+	for _, tl := range taskLists {
+		entries = append(entries, trashEntry{id: tl.ID, label: tl.Label, isList: true, deletedAt: tl.DeletedAt.Time})
+	}
+	for _, t := range tasks {
+		entries = append(entries, trashEntry{id: t.ID, label: t.Label, deletedAt: t.DeletedAt.Time})
+	}
+	slices.SortStableFunc(entries, func(a, b trashEntry) int {
+		return b.deletedAt.Compare(a.deletedAt)
+	})
+
+	listView := widget.NewList(
+		func() int {
+			return len(entries)
+		},
+		func() fyne.CanvasObject {
+			return container.NewStack(widget.NewLabel("Loading..."))
+		},
+		func(id widget.ListItemID, object fyne.CanvasObject) {
+			entry := entries[id]
+
+			content := object.(*fyne.Container)
+
+			content.RemoveAll()
+
+			kindIcon := widget.NewIcon(theme.FileIcon())
+			if entry.isList {
+				kindIcon.SetResource(theme.ListIcon())
+			}
+
+			labelText := canvas.NewText(entry.label, theme.Color(theme.ColorNameForeground))
+			ResizeTextToFit(labelText, 14, 200)
+			deletedText := canvas.NewText(fmt.Sprintf("Deleted %s", FormatDateTime(entry.deletedAt)), theme.Color(theme.ColorNameForeground))
+			deletedText.TextSize = 10
+
+			content.Add(container.NewBorder(
+				nil,
+				nil,
+				kindIcon,
+				container.NewHBox(
+					widget.NewButtonWithIcon("", theme.ContentUndoIcon(), func() {
+						v.restore(entry)
+					}),
+					widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+						v.confirmPurge(entry)
+					}),
+				),
+				container.NewVBox(labelText, deletedText),
+			))
+		},
+	)
+
+	emptyBtn := widget.NewButtonWithIcon("Empty trash", theme.DeleteIcon(), v.confirmEmpty)
+	if len(entries) == 0 {
+		emptyBtn.Disable()
+	}
+
+	ftr := container.NewBorder(
+		nil,
+		nil,
+		canvas.NewText(fmt.Sprintf("Total items: %d", len(entries)), theme.Color(theme.ColorNameForeground)),
+		emptyBtn,
+	)
+
+	return container.NewBorder(
+		nil,
+		ftr,
+		nil,
+		nil,
+		listView,
+	)
+}
+
+func (v *TrashView) restore(entry trashEntry) {
+	var err error
+	if entry.isList {
+		err = RestoreTaskList(v.app.DB(), entry.id)
+	} else {
+		err = RestoreTask(v.app.DB(), entry.id)
+	}
+	if err != nil {
+		v.app.ReportError(fmt.Sprintf("Error restoring %s", entry.label), err, func() { v.restore(entry) })
+		return
+	}
+	v.app.RefreshView()
+}
+
+func (v *TrashView) confirmPurge(entry trashEntry) {
+	dialog.ShowConfirm(
+		"Delete forever?",
+		fmt.Sprintf("%s will be permanently deleted. This can't be undone.", entry.label),
+		func(ok bool) {
+			if ok {
+				v.purge(entry)
+			}
+		},
+		v.app.window,
+	)
+}
+
+func (v *TrashView) purge(entry trashEntry) {
+	var err error
+	if entry.isList {
+		err = PurgeTaskList(v.app.DB(), entry.id)
+	} else {
+		err = PurgeTask(v.app.DB(), entry.id)
+	}
+	if err != nil {
+		v.app.ReportError(fmt.Sprintf("Error deleting %s", entry.label), err, func() { v.purge(entry) })
+		return
+	}
+	v.app.RefreshView()
+}
+
+func (v *TrashView) confirmEmpty() {
+	dialog.ShowConfirm(
+		"Empty trash?",
+		"Everything in the trash will be permanently deleted. This can't be undone.",
+		func(ok bool) {
+			if ok {
+				v.empty()
+			}
+		},
+		v.app.window,
+	)
+}
+
+func (v *TrashView) empty() {
+	if _, err := PurgeTrash(v.app.DB(), time.Now()); err != nil {
+		v.app.ReportError("Error emptying trash", err, v.empty)
+		return
+	}
+	v.app.RefreshView()
+}
+
+func (v *TrashView) Background() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.background()
+}
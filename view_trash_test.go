@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestTrashViewRestoreStaysInHistory(t *testing.T) {
+	ta, db := newTestTaskApp(t)
+	task := createTestTask(t, db, Task{Label: "Dishes"})
+	if err := db.Delete(&task).Error; err != nil {
+		t.Fatal(err)
+	}
+	ta.RenderHomeView()
+	ta.RenderTrashView()
+	view := ta.activeView.(*TrashView)
+	history := len(ta.history)
+
+	view.restore(trashEntry{id: task.ID, label: task.Label})
+
+	if ta.activeView != view {
+		t.Error("restoring left the trash view")
+	}
+	if len(ta.history) != history {
+		t.Errorf("history grew from %d to %d views", history, len(ta.history))
+	}
+}